@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os/signal"
+	"syscall"
+	"todo-app/internal/config"
+	"todo-app/internal/gateway/messaging"
+)
+
+// Mengirim ulang message dari dead-letter topic ke topic asalnya, contoh:
+//
+//	go run cmd/replay/main.go -topic card.moved -dry-run
+func main() {
+	topic := flag.String("topic", "", "original topic of the dead letters, e.g. card.moved")
+	dryRun := flag.Bool("dry-run", false, "only list the dead letters without replaying them")
+	flag.Parse()
+
+	viperConfig := config.NewViper()
+	log := config.NewLogger(viperConfig)
+	if *topic == "" {
+		log.Fatal("-topic is required")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	producer := config.NewKafkaSyncProducer(viperConfig, log)
+	defer producer.Close()
+	consumerGroup := config.NewKafkaReplayConsumerGroup(viperConfig, log)
+	defer consumerGroup.Close()
+
+	// Replay selesai setelah setiap partition dibaca sampai offset terakhir saat replay dimulai
+	deadLetterTopic := messaging.DeadLetterTopic(*topic)
+	replayer := messaging.NewDeadLetterReplayer(producer, log, *dryRun)
+	if err := replayer.Replay(ctx, consumerGroup, deadLetterTopic); err != nil {
+		log.Fatalf("Failed to replay topic %s: %v", deadLetterTopic, err)
+	}
+
+	if failed := replayer.Failed.Load(); failed > 0 {
+		log.Fatalf("Replayed %d messages from %s, %d partitions stopped on an error", replayer.Replayed.Load(), deadLetterTopic, failed)
+	}
+	log.Infof("Replayed %d messages from %s", replayer.Replayed.Load(), deadLetterTopic)
+}
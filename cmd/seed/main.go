@@ -36,6 +36,9 @@ func main() {
 	if err := seed.SeedRoles(db); err != nil {
 		log.Fatal(err)
 	}
+	if err := seed.SeedRolePermissions(db); err != nil {
+		log.Fatal(err)
+	}
 	if err := seed.SeedDepartments(db); err != nil {
 		log.Fatal(err)
 	}
@@ -11,6 +11,8 @@ func main() {
 	db := config.NewDatabase(viperConfig, log)
 	validate := config.NewValidator(viperConfig)
 	app := config.NewFiber(viperConfig)
+	mailer := config.NewMailer(viperConfig, log)
+	signer := config.NewJWTSigner(viperConfig, log)
 
 	config.Bootstrap(&config.BootstrapConfig{
 		DB:       db,
@@ -18,6 +20,8 @@ func main() {
 		Log:      log,
 		Validate: validate,
 		Config:   viperConfig,
+		Mailer:   mailer,
+		Signer:   signer,
 	})
 
 	webPort := viperConfig.GetInt("WEB_PORT")
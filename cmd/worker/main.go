@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"os/signal"
+	"syscall"
+	"todo-app/internal/config"
+	"todo-app/internal/gateway/search"
+)
+
+func main() {
+	viperConfig := config.NewViper()
+	log := config.NewLogger(viperConfig)
+	db := config.NewDatabase(viperConfig, log)
+	mailer := config.NewMailer(viperConfig, log)
+	producer := config.NewKafkaSyncProducer(viperConfig, log)
+	defer producer.Close()
+
+	// SIGTERM dari orchestrator menghentikan consume, message yang sedang diproses diselesaikan dulu
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log.Info("Starting worker")
+	err := config.BootstrapWorker(ctx, &config.WorkerConfig{
+		DB:            db,
+		Log:           log,
+		Mailer:        mailer,
+		Indexer:       search.NewLogIndexer(log),
+		Producer:      producer,
+		RetryPolicy:   config.NewKafkaRetryPolicy(viperConfig),
+		Outbox:        config.NewOutboxConfig(viperConfig),
+		ConsumerGroup: config.NewKafkaConsumerGroup(viperConfig, log),
+	})
+	if err != nil {
+		log.Fatalf("Worker stopped: %v", err)
+	}
+
+	log.Info("Worker stopped")
+}
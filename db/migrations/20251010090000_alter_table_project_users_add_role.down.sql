@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS uq_project_users_project_user;
+ALTER TABLE project_users
+		DROP CONSTRAINT IF EXISTS chk_project_users_role,
+		DROP COLUMN IF EXISTS role;
@@ -0,0 +1,9 @@
+ALTER TABLE project_users
+ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'viewer',
+ADD CONSTRAINT chk_project_users_role
+    CHECK (role IN ('owner', 'maintainer', 'viewer'));
+
+-- satu user hanya boleh punya satu membership aktif per project
+CREATE UNIQUE INDEX IF NOT EXISTS uq_project_users_project_user
+    ON project_users (project_id, user_id)
+    WHERE deleted_at IS NULL;
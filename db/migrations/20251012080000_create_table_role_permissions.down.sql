@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS update_role_permissions_updated_at ON role_permissions;
+DROP FUNCTION IF EXISTS update_role_permissions_updated_at_column;
+DROP TABLE IF EXISTS role_permissions;
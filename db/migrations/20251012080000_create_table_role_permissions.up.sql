@@ -0,0 +1,26 @@
+CREATE TABLE role_permissions (
+    id          VARCHAR(100) PRIMARY KEY,
+    role_id     VARCHAR(100) NOT NULL,
+    permission  VARCHAR(100) NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_role_permissions_role FOREIGN KEY (role_id)
+        REFERENCES roles (id)
+        ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT uq_role_permissions_role_permission UNIQUE (role_id, permission)
+);
+
+-- function untuk auto update kolom updated_at
+CREATE OR REPLACE FUNCTION update_role_permissions_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+   NEW.updated_at = now();
+   RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- trigger pasang ke tabel role_permissions
+CREATE TRIGGER update_role_permissions_updated_at
+BEFORE UPDATE ON role_permissions
+FOR EACH ROW
+EXECUTE FUNCTION update_role_permissions_updated_at_column();
@@ -0,0 +1,16 @@
+-- token_id terisi untuk revoke satu access token (jti),
+-- token_id NULL berarti semua token user yang terbit sebelum revoked_at ikut dicabut
+CREATE TABLE token_revocations (
+    id          VARCHAR(100) PRIMARY KEY,
+    user_id     VARCHAR(100) NOT NULL,
+    token_id    VARCHAR(100) NULL,
+    revoked_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at  TIMESTAMP NOT NULL,
+    CONSTRAINT fk_token_revocations_user FOREIGN KEY (user_id)
+        REFERENCES users (id)
+        ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_token_revocations_token_id ON token_revocations (token_id);
+CREATE INDEX idx_token_revocations_user_id ON token_revocations (user_id, revoked_at);
+CREATE INDEX idx_token_revocations_expires_at ON token_revocations (expires_at);
@@ -0,0 +1,33 @@
+CREATE TABLE refresh_tokens (
+    id           VARCHAR(100) PRIMARY KEY,
+    user_id      VARCHAR(100) NOT NULL,
+    family_id    VARCHAR(100) NOT NULL,
+    token_hash   VARCHAR(100) NOT NULL UNIQUE,
+    expires_at   TIMESTAMP NOT NULL,
+    rotated_at   TIMESTAMP NULL,
+    replaced_by  VARCHAR(100) NULL,
+    revoked_at   TIMESTAMP NULL,
+    created_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_refresh_tokens_user FOREIGN KEY (user_id)
+        REFERENCES users (id)
+        ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens (family_id);
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens (user_id);
+
+-- function untuk auto update kolom updated_at
+CREATE OR REPLACE FUNCTION update_refresh_tokens_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+   NEW.updated_at = now();
+   RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- trigger pasang ke tabel refresh_tokens
+CREATE TRIGGER update_refresh_tokens_updated_at
+BEFORE UPDATE ON refresh_tokens
+FOR EACH ROW
+EXECUTE FUNCTION update_refresh_tokens_updated_at_column();
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_token_revocations_session_id;
+ALTER TABLE token_revocations
+		DROP COLUMN IF EXISTS session_id;
+
+DROP TRIGGER IF EXISTS update_user_sessions_updated_at ON user_sessions;
+DROP FUNCTION IF EXISTS update_user_sessions_updated_at_column;
+DROP TABLE IF EXISTS user_sessions;
@@ -0,0 +1,38 @@
+CREATE TABLE user_sessions (
+    id            VARCHAR(100) PRIMARY KEY,
+    user_id       VARCHAR(100) NOT NULL,
+    device_label  VARCHAR(100) NULL,
+    ip_address    VARCHAR(100) NULL,
+    user_agent    VARCHAR(500) NULL,
+    last_seen_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at    TIMESTAMP NOT NULL,
+    revoked_at    TIMESTAMP NULL,
+    created_at    TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at    TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_user_sessions_user FOREIGN KEY (user_id)
+        REFERENCES users (id)
+        ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_user_sessions_user_id ON user_sessions (user_id);
+
+-- function untuk auto update kolom updated_at
+CREATE OR REPLACE FUNCTION update_user_sessions_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+   NEW.updated_at = now();
+   RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- trigger pasang ke tabel user_sessions
+CREATE TRIGGER update_user_sessions_updated_at
+BEFORE UPDATE ON user_sessions
+FOR EACH ROW
+EXECUTE FUNCTION update_user_sessions_updated_at_column();
+
+-- session_id terisi berarti semua access token dari session tersebut dicabut
+ALTER TABLE token_revocations
+ADD COLUMN IF NOT EXISTS session_id VARCHAR(100) NULL;
+
+CREATE INDEX idx_token_revocations_session_id ON token_revocations (session_id);
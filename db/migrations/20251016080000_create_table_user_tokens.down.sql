@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS update_user_tokens_updated_at ON user_tokens;
+DROP FUNCTION IF EXISTS update_user_tokens_updated_at_column;
+ALTER TABLE users ALTER COLUMN is_active SET DEFAULT TRUE;
+DROP TABLE IF EXISTS user_tokens;
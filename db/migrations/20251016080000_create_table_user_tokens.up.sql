@@ -0,0 +1,33 @@
+CREATE TABLE user_tokens (
+    id           VARCHAR(100) PRIMARY KEY,
+    user_id      VARCHAR(100) NOT NULL,
+    purpose      VARCHAR(50) NOT NULL,
+    token_hash   VARCHAR(100) NOT NULL UNIQUE,
+    expires_at   TIMESTAMP NOT NULL,
+    used_at      TIMESTAMP NULL,
+    created_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_user_tokens_user FOREIGN KEY (user_id)
+        REFERENCES users (id)
+        ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_user_tokens_user_id_purpose ON user_tokens (user_id, purpose);
+
+-- user hasil registrasi baru aktif setelah verifikasi email
+ALTER TABLE users ALTER COLUMN is_active SET DEFAULT FALSE;
+
+-- function untuk auto update kolom updated_at
+CREATE OR REPLACE FUNCTION update_user_tokens_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+   NEW.updated_at = now();
+   RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- trigger pasang ke tabel user_tokens
+CREATE TRIGGER update_user_tokens_updated_at
+BEFORE UPDATE ON user_tokens
+FOR EACH ROW
+EXECUTE FUNCTION update_user_tokens_updated_at_column();
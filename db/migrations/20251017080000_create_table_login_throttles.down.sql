@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS update_login_throttles_updated_at ON login_throttles;
+DROP FUNCTION IF EXISTS update_login_throttles_updated_at_column;
+DROP TABLE IF EXISTS login_throttles;
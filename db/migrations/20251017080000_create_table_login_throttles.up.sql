@@ -0,0 +1,24 @@
+CREATE TABLE login_throttles (
+    throttle_key     VARCHAR(150) PRIMARY KEY,
+    failed_count     INT NOT NULL DEFAULT 0,
+    last_failed_at   TIMESTAMP NOT NULL,
+    next_attempt_at  TIMESTAMP NULL,
+    locked_until     TIMESTAMP NULL,
+    created_at       TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- function untuk auto update kolom updated_at
+CREATE OR REPLACE FUNCTION update_login_throttles_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+   NEW.updated_at = now();
+   RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- trigger pasang ke tabel login_throttles
+CREATE TRIGGER update_login_throttles_updated_at
+BEFORE UPDATE ON login_throttles
+FOR EACH ROW
+EXECUTE FUNCTION update_login_throttles_updated_at_column();
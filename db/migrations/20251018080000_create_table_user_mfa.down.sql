@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS update_user_mfa_updated_at ON user_mfa;
+DROP FUNCTION IF EXISTS update_user_mfa_updated_at_column;
+ALTER TABLE roles DROP COLUMN IF EXISTS mfa_required;
+DROP TABLE IF EXISTS user_recovery_codes;
+DROP TABLE IF EXISTS user_mfa;
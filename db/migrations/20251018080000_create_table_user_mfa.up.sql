@@ -0,0 +1,42 @@
+CREATE TABLE user_mfa (
+    user_id         VARCHAR(100) PRIMARY KEY,
+    totp_secret     VARCHAR(100) NOT NULL,
+    confirmed_at    TIMESTAMP NULL,
+    last_used_step  BIGINT NOT NULL DEFAULT 0,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_user_mfa_user FOREIGN KEY (user_id)
+        REFERENCES users (id)
+        ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE user_recovery_codes (
+    id          VARCHAR(100) PRIMARY KEY,
+    user_id     VARCHAR(100) NOT NULL,
+    code_hash   VARCHAR(100) NOT NULL UNIQUE,
+    used_at     TIMESTAMP NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_user_recovery_codes_user FOREIGN KEY (user_id)
+        REFERENCES users (id)
+        ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_user_recovery_codes_user_id ON user_recovery_codes (user_id);
+
+-- role yang wajib memakai MFA
+ALTER TABLE roles ADD COLUMN mfa_required BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- function untuk auto update kolom updated_at
+CREATE OR REPLACE FUNCTION update_user_mfa_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+   NEW.updated_at = now();
+   RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- trigger pasang ke tabel user_mfa
+CREATE TRIGGER update_user_mfa_updated_at
+BEFORE UPDATE ON user_mfa
+FOR EACH ROW
+EXECUTE FUNCTION update_user_mfa_updated_at_column();
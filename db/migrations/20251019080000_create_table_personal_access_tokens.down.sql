@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS update_personal_access_tokens_updated_at ON personal_access_tokens;
+DROP FUNCTION IF EXISTS update_personal_access_tokens_updated_at_column;
+DROP TABLE IF EXISTS personal_access_tokens;
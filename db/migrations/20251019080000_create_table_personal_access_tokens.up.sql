@@ -0,0 +1,33 @@
+CREATE TABLE personal_access_tokens (
+    id            VARCHAR(100) PRIMARY KEY,
+    user_id       VARCHAR(100) NOT NULL,
+    name          VARCHAR(100) NOT NULL,
+    token_prefix  VARCHAR(20) NOT NULL,
+    token_hash    VARCHAR(100) NOT NULL UNIQUE,
+    scopes        TEXT NOT NULL,
+    expires_at    TIMESTAMP NULL,
+    last_used_at  TIMESTAMP NULL,
+    revoked_at    TIMESTAMP NULL,
+    created_at    TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at    TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_personal_access_tokens_user FOREIGN KEY (user_id)
+        REFERENCES users (id)
+        ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_personal_access_tokens_user_id ON personal_access_tokens (user_id);
+
+-- function untuk auto update kolom updated_at
+CREATE OR REPLACE FUNCTION update_personal_access_tokens_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+   NEW.updated_at = now();
+   RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- trigger pasang ke tabel personal_access_tokens
+CREATE TRIGGER update_personal_access_tokens_updated_at
+BEFORE UPDATE ON personal_access_tokens
+FOR EACH ROW
+EXECUTE FUNCTION update_personal_access_tokens_updated_at_column();
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS update_user_identities_updated_at ON user_identities;
+DROP FUNCTION IF EXISTS update_user_identities_updated_at_column;
+DROP TABLE IF EXISTS oidc_login_states;
+DROP TABLE IF EXISTS user_identities;
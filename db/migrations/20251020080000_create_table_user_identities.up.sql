@@ -0,0 +1,44 @@
+CREATE TABLE user_identities (
+    id             VARCHAR(100) PRIMARY KEY,
+    user_id        VARCHAR(100) NOT NULL,
+    issuer         VARCHAR(255) NOT NULL,
+    subject        VARCHAR(255) NOT NULL,
+    email          VARCHAR(100) NOT NULL,
+    last_login_at  TIMESTAMP NULL,
+    created_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_user_identities_issuer_subject UNIQUE (issuer, subject),
+    CONSTRAINT fk_user_identities_user FOREIGN KEY (user_id)
+        REFERENCES users (id)
+        ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities (user_id);
+
+-- state login OIDC yang sedang berjalan, menyimpan code_verifier PKCE dan nonce sampai callback
+CREATE TABLE oidc_login_states (
+    id             VARCHAR(100) PRIMARY KEY,
+    state_hash     VARCHAR(100) NOT NULL UNIQUE,
+    code_verifier  VARCHAR(100) NOT NULL,
+    nonce          VARCHAR(100) NOT NULL,
+    expires_at     TIMESTAMP NOT NULL,
+    used_at        TIMESTAMP NULL,
+    created_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_oidc_login_states_expires_at ON oidc_login_states (expires_at);
+
+-- function untuk auto update kolom updated_at
+CREATE OR REPLACE FUNCTION update_user_identities_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+   NEW.updated_at = now();
+   RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- trigger pasang ke tabel user_identities
+CREATE TRIGGER update_user_identities_updated_at
+BEFORE UPDATE ON user_identities
+FOR EACH ROW
+EXECUTE FUNCTION update_user_identities_updated_at_column();
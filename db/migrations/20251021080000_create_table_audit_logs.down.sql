@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS prevent_audit_logs_truncate ON audit_logs;
+DROP TRIGGER IF EXISTS prevent_audit_logs_update_delete ON audit_logs;
+DROP FUNCTION IF EXISTS prevent_audit_logs_modification;
+DROP TABLE IF EXISTS audit_logs;
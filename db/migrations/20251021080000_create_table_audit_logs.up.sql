@@ -0,0 +1,36 @@
+CREATE TABLE audit_logs (
+    id           VARCHAR(100) PRIMARY KEY,
+    actor_id     VARCHAR(100) NULL,
+    action       VARCHAR(50) NOT NULL,
+    entity_type  VARCHAR(100) NOT NULL,
+    entity_id    VARCHAR(100) NOT NULL,
+    before_data  JSONB NULL,
+    after_data   JSONB NULL,
+    ip_address   VARCHAR(100) NULL,
+    request_id   VARCHAR(100) NULL,
+    created_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- actor_id sengaja tanpa foreign key supaya jejak audit tetap ada walaupun user-nya di-force delete
+CREATE INDEX idx_audit_logs_created_at ON audit_logs (created_at);
+CREATE INDEX idx_audit_logs_actor_id ON audit_logs (actor_id, created_at);
+CREATE INDEX idx_audit_logs_entity ON audit_logs (entity_type, entity_id, created_at);
+
+-- function untuk menolak perubahan, audit log hanya boleh ditambah
+CREATE OR REPLACE FUNCTION prevent_audit_logs_modification()
+RETURNS TRIGGER AS $$
+BEGIN
+   RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+-- trigger pasang ke tabel audit_logs
+CREATE TRIGGER prevent_audit_logs_update_delete
+BEFORE UPDATE OR DELETE ON audit_logs
+FOR EACH ROW
+EXECUTE FUNCTION prevent_audit_logs_modification();
+
+CREATE TRIGGER prevent_audit_logs_truncate
+BEFORE TRUNCATE ON audit_logs
+FOR EACH STATEMENT
+EXECUTE FUNCTION prevent_audit_logs_modification();
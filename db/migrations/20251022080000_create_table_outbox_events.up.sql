@@ -0,0 +1,14 @@
+CREATE TABLE outbox_events (
+    id           BIGSERIAL PRIMARY KEY,
+    topic        VARCHAR(255) NOT NULL,
+    event_key    VARCHAR(100) NOT NULL,
+    payload      JSONB NOT NULL,
+    attempts     INT NOT NULL DEFAULT 0,
+    last_error   TEXT NULL,
+    created_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMP NULL
+);
+
+-- relay hanya membaca event yang belum terkirim, urut sesuai id
+CREATE INDEX idx_outbox_events_pending ON outbox_events (id) WHERE published_at IS NULL;
+CREATE INDEX idx_outbox_events_published_at ON outbox_events (published_at) WHERE published_at IS NOT NULL;
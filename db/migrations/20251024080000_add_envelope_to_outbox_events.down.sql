@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_outbox_events_event_id;
+
+ALTER TABLE outbox_events
+    DROP COLUMN IF EXISTS correlation_id,
+    DROP COLUMN IF EXISTS event_version,
+    DROP COLUMN IF EXISTS event_type,
+    DROP COLUMN IF EXISTS event_id;
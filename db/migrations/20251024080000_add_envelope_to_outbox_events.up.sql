@@ -0,0 +1,15 @@
+ALTER TABLE outbox_events
+    ADD COLUMN event_id       VARCHAR(100) NULL,
+    ADD COLUMN event_type     VARCHAR(255) NULL,
+    ADD COLUMN event_version  INT NOT NULL DEFAULT 1,
+    ADD COLUMN correlation_id VARCHAR(100) NULL;
+
+-- event yang ditulis sebelum ada envelope memakai nama topic sebagai tipe event versi 1
+UPDATE outbox_events SET event_id = gen_random_uuid()::text WHERE event_id IS NULL;
+UPDATE outbox_events SET event_type = topic WHERE event_type IS NULL;
+
+ALTER TABLE outbox_events
+    ALTER COLUMN event_id SET NOT NULL,
+    ALTER COLUMN event_type SET NOT NULL;
+
+CREATE UNIQUE INDEX idx_outbox_events_event_id ON outbox_events (event_id);
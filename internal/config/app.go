@@ -4,8 +4,10 @@ import (
 	"todo-app/internal/delivery/http"
 	"todo-app/internal/delivery/http/middleware"
 	"todo-app/internal/delivery/http/route"
+	"todo-app/internal/gateway/mail"
 	"todo-app/internal/repository"
 	"todo-app/internal/usecase"
+	"todo-app/internal/util/helper"
 
 	"github.com/IBM/sarama"
 	"github.com/go-playground/validator/v10"
@@ -22,29 +24,105 @@ type BootstrapConfig struct {
 	Validate *validator.Validate
 	Config   *viper.Viper
 	Producer sarama.SyncProducer
+	Mailer   mail.Mailer
+	Signer   *helper.JWTSigner
 }
 
 func Bootstrap(config *BootstrapConfig) {
 	// setup repositories
 	userRepository := repository.NewUserRepository(config.Log)
 	roleRepository := repository.NewRoleRepository(config.Log)
+	rolePermissionRepository := repository.NewRolePermissionRepository(config.Log)
+	departmentRepository := repository.NewDepartmentRepository(config.Log)
+	tokenRevocationRepository := repository.NewTokenRevocationRepository(config.Log)
+	refreshTokenRepository := repository.NewRefreshTokenRepository(config.Log)
+	userSessionRepository := repository.NewUserSessionRepository(config.Log)
+	userTokenRepository := repository.NewUserTokenRepository(config.Log)
+	loginThrottleRepository := repository.NewLoginThrottleRepository(config.Log)
+	userMfaRepository := repository.NewUserMfaRepository(config.Log)
+	userRecoveryCodeRepository := repository.NewUserRecoveryCodeRepository(config.Log)
+	personalAccessTokenRepository := repository.NewPersonalAccessTokenRepository(config.Log)
+	userIdentityRepository := repository.NewUserIdentityRepository(config.Log)
+	oidcLoginStateRepository := repository.NewOidcLoginStateRepository(config.Log)
+	projectRepository := repository.NewProjectRepository(config.Log)
+	projectUserRepository := repository.NewProjectUserRepository(config.Log)
+	boardRepository := repository.NewBoardRepository(config.Log)
+	cardRepository := repository.NewCardRepository(config.Log)
+	auditLogRepository := repository.NewAuditLogRepository(config.Log)
+	outboxEventRepository := repository.NewOutboxEventRepository(config.Log)
+
+	loginThrottleConfig := NewLoginThrottleConfig(config.Config)
+	mfaConfig := NewMfaConfig(config.Config)
 
 	// setup use cases
-	userUseCase := usecase.NewUserUseCase(config.DB, config.Log, config.Validate, userRepository)
-	roleUseCase := usecase.NewRoleUseCase(config.DB, config.Log, config.Validate, roleRepository)
+	userUseCase := usecase.NewUserUseCase(config.DB, config.Log, config.Validate, userRepository, departmentRepository, tokenRevocationRepository, refreshTokenRepository, userSessionRepository, userTokenRepository,
+		loginThrottleRepository, loginThrottleConfig, NewPasswordPolicy(config.Config),
+		userMfaRepository, userRecoveryCodeRepository, roleRepository, config.Mailer, config.Signer, NewUserTokenConfig(config.Config, config.Log), mfaConfig,
+		auditLogRepository, outboxEventRepository)
+	oidcUseCase := usecase.NewOidcUseCase(config.DB, config.Log, config.Validate, NewOidcProvider(config.Config, config.Log), NewOidcConfig(config.Config),
+		userUseCase, userRepository, userIdentityRepository, oidcLoginStateRepository, roleRepository, departmentRepository,
+		userMfaRepository, userSessionRepository, refreshTokenRepository, tokenRevocationRepository, auditLogRepository,
+		outboxEventRepository)
+	userAdminUseCase := usecase.NewUserAdminUseCase(config.DB, config.Log, config.Validate, userRepository, roleRepository,
+		departmentRepository, projectUserRepository, userSessionRepository, refreshTokenRepository, tokenRevocationRepository, config.Signer, auditLogRepository, outboxEventRepository)
+	personalAccessTokenUseCase := usecase.NewPersonalAccessTokenUseCase(config.DB, config.Log, config.Validate, personalAccessTokenRepository, userRepository, rolePermissionRepository, auditLogRepository)
+	mfaUseCase := usecase.NewMfaUseCase(config.DB, config.Log, config.Validate, mfaConfig, userMfaRepository, userRecoveryCodeRepository, userRepository, roleRepository,
+		loginThrottleRepository, loginThrottleConfig, auditLogRepository)
+	sessionUseCase := usecase.NewSessionUseCase(config.DB, config.Log, config.Validate, userSessionRepository, refreshTokenRepository, tokenRevocationRepository, config.Signer, auditLogRepository)
+	roleUseCase := usecase.NewRoleUseCase(config.DB, config.Log, config.Validate, roleRepository, auditLogRepository)
+	permissionUseCase := usecase.NewPermissionUseCase(config.DB, config.Log, config.Validate, roleRepository, rolePermissionRepository, auditLogRepository)
+	departmentUseCase := usecase.NewDepartmentUseCase(config.DB, config.Log, config.Validate, departmentRepository, userRepository, projectRepository, auditLogRepository)
+	projectUseCase := usecase.NewProjectUseCase(config.DB, config.Log, config.Validate, projectRepository, projectUserRepository, auditLogRepository)
+	projectMemberUseCase := usecase.NewProjectMemberUseCase(config.DB, config.Log, config.Validate, projectUserRepository, userRepository, auditLogRepository)
+	boardUseCase := usecase.NewBoardUseCase(config.DB, config.Log, config.Validate, boardRepository, projectUserRepository, auditLogRepository)
+	cardUseCase := usecase.NewCardUseCase(config.DB, config.Log, config.Validate, cardRepository, boardRepository, projectUserRepository, auditLogRepository,
+		outboxEventRepository)
+	auditLogUseCase := usecase.NewAuditLogUseCase(config.DB, config.Log, config.Validate, auditLogRepository)
 
 	// setup controller
 	userController := http.NewUserController(userUseCase, config.Log)
+	sessionController := http.NewSessionController(sessionUseCase, config.Log)
+	mfaController := http.NewMfaController(mfaUseCase, config.Log)
+	oidcController := http.NewOidcController(oidcUseCase, config.Log)
+	jwksController := http.NewJWKSController(config.Signer, config.Log)
+	userAdminController := http.NewUserAdminController(userAdminUseCase, config.Log)
+	tokenController := http.NewPersonalAccessTokenController(personalAccessTokenUseCase, config.Log)
 	roleController := http.NewRoleController(roleUseCase, config.Log)
+	permissionController := http.NewPermissionController(permissionUseCase, config.Log)
+	departmentController := http.NewDepartmentController(departmentUseCase, config.Log)
+	projectController := http.NewProjectController(projectUseCase, config.Log)
+	boardController := http.NewBoardController(boardUseCase, config.Log)
+	cardController := http.NewCardController(cardUseCase, config.Log)
+	memberController := http.NewProjectMemberController(projectMemberUseCase, config.Log)
+	auditLogController := http.NewAuditLogController(auditLogUseCase, config.Log)
 
 	// setup middleware
-	authMiddleware := middleware.NewAuth(userUseCase)
+	auditMiddleware := middleware.NewAuditContext()
+	authMiddleware := middleware.NewAuth(userUseCase, personalAccessTokenUseCase)
+	mfaMiddleware := middleware.NewMfaEnrollment()
+	requirePermission := middleware.NewPermission(permissionUseCase)
 
 	routeConfig := route.RouteConfig{
-		App:            config.App,
-		UserController: userController,
-		RoleController: roleController,
-		AuthMiddleware: authMiddleware,
+		App:                  config.App,
+		UserController:       userController,
+		SessionController:    sessionController,
+		MfaController:        mfaController,
+		OidcController:       oidcController,
+		JWKSController:       jwksController,
+		UserAdminController:  userAdminController,
+		TokenController:      tokenController,
+		RoleController:       roleController,
+		PermissionController: permissionController,
+		ProjectController:    projectController,
+		BoardController:      boardController,
+		CardController:       cardController,
+		MemberController:     memberController,
+		DepartmentController: departmentController,
+		AuditLogController:   auditLogController,
+		AuditMiddleware:      auditMiddleware,
+		AuthMiddleware:       authMiddleware,
+		MfaMiddleware:        mfaMiddleware,
+		RequirePermission:    requirePermission,
 	}
 	routeConfig.Setup()
 }
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"os"
+	"strings"
+	"time"
+	"todo-app/internal/usecase"
+	"todo-app/internal/util/helper"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// NewJWTSigner loads the access token signing keys from JWT_SIGNING_KEYS ("kid=path.pem,kid=path.pem").
+// JWT_ACTIVE_KEY_ID picks the key that signs new tokens (default the first one); every listed key verifies
+// and is published in the JWKS, so a new key can be added before it becomes active and an old key kept until its tokens expire.
+// Without JWT_SIGNING_KEYS the app refuses to start, unless JWT_EPHEMERAL_KEY=true allows a throwaway key for local development.
+func NewJWTSigner(viper *viper.Viper, log *logrus.Logger) *helper.JWTSigner {
+	viper.SetDefault("JWT_EXPIRATION_HOURS", 1)
+	viper.SetDefault("JWT_REFRESH_EXPIRATION_HOURS", 24*30)
+	viper.SetDefault("JWT_ISSUER", "todo-app")
+	viper.SetDefault("JWT_EPHEMERAL_KEY", false)
+
+	var keys []*helper.SigningKey
+	for _, item := range strings.Split(viper.GetString("JWT_SIGNING_KEYS"), ",") {
+		kid, path, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok {
+			continue
+		}
+
+		pemBytes, err := os.ReadFile(strings.TrimSpace(path))
+		if err != nil {
+			log.Fatalf("failed to read jwt key %s: %v", kid, err)
+		}
+
+		key, err := helper.ParseSigningKey(strings.TrimSpace(kid), pemBytes)
+		if err != nil {
+			log.Fatalf("failed to load jwt key: %v", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		if !viper.GetBool("JWT_EPHEMERAL_KEY") {
+			log.Fatal("JWT_SIGNING_KEYS is not set, set JWT_EPHEMERAL_KEY=true to sign with a throwaway key in development")
+		}
+		log.Warn("JWT_SIGNING_KEYS is not set, using an ephemeral key; tokens will not survive a restart")
+		publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			log.Fatalf("failed to generate jwt key: %v", err)
+		}
+		keys = append(keys, &helper.SigningKey{
+			Id:         "ephemeral",
+			Method:     jwt.SigningMethodEdDSA,
+			PrivateKey: privateKey,
+			PublicKey:  publicKey,
+		})
+	}
+
+	activeKeyId := viper.GetString("JWT_ACTIVE_KEY_ID")
+	if activeKeyId == "" {
+		activeKeyId = keys[0].Id
+	}
+
+	signer, err := helper.NewJWTSigner(
+		viper.GetString("JWT_ISSUER"),
+		time.Duration(viper.GetInt("JWT_EXPIRATION_HOURS"))*time.Hour,
+		time.Duration(viper.GetInt("JWT_REFRESH_EXPIRATION_HOURS"))*time.Hour,
+		keys,
+		activeKeyId,
+	)
+	if err != nil {
+		log.Fatalf("failed to create jwt signer: %v", err)
+	}
+
+	return signer
+}
+
+// NewUserTokenConfig loads the HMAC secret, lifetimes and links of the tokens sent by email, the secret is required
+func NewUserTokenConfig(viper *viper.Viper, log *logrus.Logger) *usecase.UserTokenConfig {
+	viper.SetDefault("EMAIL_VERIFICATION_EXPIRATION_HOURS", 24)
+	viper.SetDefault("PASSWORD_RESET_EXPIRATION_MINUTES", 60)
+
+	secret := viper.GetString("JWT_SECRET")
+	if secret == "" {
+		log.Fatal("JWT_SECRET is not set, it signs the email verification and password reset tokens")
+	}
+
+	return &usecase.UserTokenConfig{
+		Secret:               []byte(secret),
+		EmailVerificationTTL: time.Duration(viper.GetInt("EMAIL_VERIFICATION_EXPIRATION_HOURS")) * time.Hour,
+		PasswordResetTTL:     time.Duration(viper.GetInt("PASSWORD_RESET_EXPIRATION_MINUTES")) * time.Minute,
+		VerifyEmailUrl:       viper.GetString("MAIL_VERIFY_EMAIL_URL"),
+		ResetPasswordUrl:     viper.GetString("MAIL_RESET_PASSWORD_URL"),
+	}
+}
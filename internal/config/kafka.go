@@ -0,0 +1,84 @@
+package config
+
+import (
+	"strings"
+	"time"
+	"todo-app/internal/gateway/messaging"
+
+	"github.com/IBM/sarama"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// NewKafkaSyncProducer connects to KAFKA_BROKERS, the worker uses it to relay the outbox and forward failed messages
+func NewKafkaSyncProducer(viper *viper.Viper, log *logrus.Logger) sarama.SyncProducer {
+	kafkaConfig := sarama.NewConfig()
+	kafkaConfig.Producer.Return.Successes = true
+	kafkaConfig.Producer.RequiredAcks = sarama.WaitForAll
+	kafkaConfig.Producer.Retry.Max = 3
+
+	producer, err := sarama.NewSyncProducer(kafkaBrokers(viper), kafkaConfig)
+	if err != nil {
+		log.Fatalf("Failed to create kafka producer: %v", err)
+	}
+
+	return producer
+}
+
+// NewKafkaConsumerGroup joins KAFKA_GROUP_ID, a new group starts from KAFKA_AUTO_OFFSET_RESET (earliest or latest)
+func NewKafkaConsumerGroup(viper *viper.Viper, log *logrus.Logger) sarama.ConsumerGroup {
+	viper.SetDefault("KAFKA_GROUP_ID", "todo-app")
+	viper.SetDefault("KAFKA_AUTO_OFFSET_RESET", "latest")
+
+	initialOffset := sarama.OffsetNewest
+	if viper.GetString("KAFKA_AUTO_OFFSET_RESET") == "earliest" {
+		initialOffset = sarama.OffsetOldest
+	}
+
+	return newKafkaConsumerGroup(viper, log, viper.GetString("KAFKA_GROUP_ID"), initialOffset)
+}
+
+// NewKafkaReplayConsumerGroup joins KAFKA_GROUP_ID with a -replay suffix, so replaying dead letters
+// does not move the offsets of the worker. A new group starts from the oldest dead letter.
+func NewKafkaReplayConsumerGroup(viper *viper.Viper, log *logrus.Logger) sarama.ConsumerGroup {
+	viper.SetDefault("KAFKA_GROUP_ID", "todo-app")
+
+	return newKafkaConsumerGroup(viper, log, viper.GetString("KAFKA_GROUP_ID")+"-replay", sarama.OffsetOldest)
+}
+
+func newKafkaConsumerGroup(viper *viper.Viper, log *logrus.Logger, groupId string, initialOffset int64) sarama.ConsumerGroup {
+	kafkaConfig := sarama.NewConfig()
+	kafkaConfig.Consumer.Offsets.Initial = initialOffset
+
+	consumerGroup, err := sarama.NewConsumerGroup(kafkaBrokers(viper), groupId, kafkaConfig)
+	if err != nil {
+		log.Fatalf("Failed to create kafka consumer group: %v", err)
+	}
+
+	return consumerGroup
+}
+
+// NewKafkaRetryPolicy reads how often a failed message is retried before it goes to the dead-letter topic
+func NewKafkaRetryPolicy(viper *viper.Viper) messaging.RetryPolicy {
+	viper.SetDefault("KAFKA_RETRY_ATTEMPTS", 3)
+	viper.SetDefault("KAFKA_RETRY_BACKOFF_MILLISECONDS", 1000)
+	viper.SetDefault("KAFKA_RETRY_TOPICS", 2)
+	viper.SetDefault("KAFKA_RETRY_DELAY_SECONDS", 60)
+
+	return messaging.RetryPolicy{
+		Attempts:    max(viper.GetInt("KAFKA_RETRY_ATTEMPTS"), 1),
+		Backoff:     time.Duration(viper.GetInt("KAFKA_RETRY_BACKOFF_MILLISECONDS")) * time.Millisecond,
+		RetryTopics: max(viper.GetInt("KAFKA_RETRY_TOPICS"), 0),
+		RetryDelay:  time.Duration(viper.GetInt("KAFKA_RETRY_DELAY_SECONDS")) * time.Second,
+	}
+}
+
+func kafkaBrokers(viper *viper.Viper) []string {
+	viper.SetDefault("KAFKA_BROKERS", "localhost:9092")
+
+	brokers := strings.Split(viper.GetString("KAFKA_BROKERS"), ",")
+	for i := range brokers {
+		brokers[i] = strings.TrimSpace(brokers[i])
+	}
+	return brokers
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"time"
+	"todo-app/internal/usecase"
+
+	"github.com/spf13/viper"
+)
+
+// NewLoginThrottleConfig loads the login brute-force limits, falling back to sane defaults
+func NewLoginThrottleConfig(viper *viper.Viper) *usecase.LoginThrottleConfig {
+	viper.SetDefault("LOGIN_MAX_ATTEMPTS", 5)
+	viper.SetDefault("LOGIN_IP_MAX_ATTEMPTS", 20)
+	viper.SetDefault("LOGIN_BACKOFF_BASE_SECONDS", 1)
+	viper.SetDefault("LOGIN_BACKOFF_MAX_SECONDS", 60)
+	viper.SetDefault("LOGIN_LOCKOUT_MINUTES", 15)
+	viper.SetDefault("LOGIN_FAILURE_WINDOW_MINUTES", 15)
+
+	return &usecase.LoginThrottleConfig{
+		MaxAttempts:     viper.GetInt("LOGIN_MAX_ATTEMPTS"),
+		IpMaxAttempts:   viper.GetInt("LOGIN_IP_MAX_ATTEMPTS"),
+		BackoffBase:     time.Duration(viper.GetInt("LOGIN_BACKOFF_BASE_SECONDS")) * time.Second,
+		BackoffMax:      time.Duration(viper.GetInt("LOGIN_BACKOFF_MAX_SECONDS")) * time.Second,
+		LockoutDuration: time.Duration(viper.GetInt("LOGIN_LOCKOUT_MINUTES")) * time.Minute,
+		FailureWindow:   time.Duration(viper.GetInt("LOGIN_FAILURE_WINDOW_MINUTES")) * time.Minute,
+	}
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"todo-app/internal/gateway/mail"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// NewMailer returns the mailer of MAIL_DRIVER. The smtp driver requires MAIL_SMTP_HOST,
+// mails are only logged when MAIL_DRIVER is set to log explicitly.
+func NewMailer(viper *viper.Viper, log *logrus.Logger) mail.Mailer {
+	viper.SetDefault("MAIL_DRIVER", "smtp")
+
+	switch driver := viper.GetString("MAIL_DRIVER"); driver {
+	case "log":
+		log.Warn("MAIL_DRIVER is log, mails will not be sent")
+		return mail.NewLogMailer(log)
+	case "smtp":
+		host := viper.GetString("MAIL_SMTP_HOST")
+		if host == "" {
+			log.Fatal("MAIL_SMTP_HOST is not set, set it or set MAIL_DRIVER to log")
+		}
+
+		return mail.NewSMTPMailer(
+			host,
+			viper.GetInt("MAIL_SMTP_PORT"),
+			viper.GetString("MAIL_SMTP_USERNAME"),
+			viper.GetString("MAIL_SMTP_PASSWORD"),
+			viper.GetString("MAIL_FROM"),
+			log,
+		)
+	default:
+		log.Fatalf("unknown MAIL_DRIVER %q, use smtp or log", driver)
+		return nil
+	}
+}
@@ -0,0 +1,19 @@
+package config
+
+import (
+	"time"
+	"todo-app/internal/usecase"
+
+	"github.com/spf13/viper"
+)
+
+// NewMfaConfig loads the issuer shown in authenticator apps and the lifetime of the login MFA challenge
+func NewMfaConfig(viper *viper.Viper) *usecase.MfaConfig {
+	viper.SetDefault("MFA_ISSUER", "Todo App")
+	viper.SetDefault("MFA_CHALLENGE_EXPIRATION_MINUTES", 5)
+
+	return &usecase.MfaConfig{
+		Issuer:       viper.GetString("MFA_ISSUER"),
+		ChallengeTTL: time.Duration(viper.GetInt("MFA_CHALLENGE_EXPIRATION_MINUTES")) * time.Minute,
+	}
+}
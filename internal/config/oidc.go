@@ -0,0 +1,64 @@
+package config
+
+import (
+	"strings"
+	"time"
+	"todo-app/internal/gateway/oidc"
+	"todo-app/internal/usecase"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// NewOidcProvider returns nil when OIDC_ISSUER_URL is not set, which disables single sign-on
+func NewOidcProvider(viper *viper.Viper, log *logrus.Logger) *oidc.Provider {
+	issuerUrl := viper.GetString("OIDC_ISSUER_URL")
+	if issuerUrl == "" {
+		log.Info("OIDC_ISSUER_URL is not set, single sign-on is disabled")
+		return nil
+	}
+
+	viper.SetDefault("OIDC_SCOPES", "openid email profile")
+	viper.SetDefault("OIDC_GROUPS_CLAIM", "groups")
+
+	return oidc.NewProvider(
+		issuerUrl,
+		viper.GetString("OIDC_CLIENT_ID"),
+		viper.GetString("OIDC_CLIENT_SECRET"),
+		viper.GetString("OIDC_REDIRECT_URL"),
+		strings.Fields(viper.GetString("OIDC_SCOPES")),
+		viper.GetString("OIDC_GROUPS_CLAIM"),
+		log,
+	)
+}
+
+// NewOidcConfig loads the group to role/department mapping, e.g. OIDC_ROLE_MAPPING=admins=<role id>,devs=<role id>
+func NewOidcConfig(viper *viper.Viper) *usecase.OidcConfig {
+	viper.SetDefault("OIDC_REQUIRE_VERIFIED_EMAIL", true)
+	viper.SetDefault("OIDC_STATE_EXPIRATION_MINUTES", 10)
+
+	return &usecase.OidcConfig{
+		RoleMapping:          parseGroupMapping(viper.GetString("OIDC_ROLE_MAPPING")),
+		DepartmentMapping:    parseGroupMapping(viper.GetString("OIDC_DEPARTMENT_MAPPING")),
+		DefaultRoleId:        viper.GetString("OIDC_DEFAULT_ROLE_ID"),
+		DefaultDepartmentId:  viper.GetString("OIDC_DEFAULT_DEPARTMENT_ID"),
+		RequireVerifiedEmail: viper.GetBool("OIDC_REQUIRE_VERIFIED_EMAIL"),
+		StateTTL:             time.Duration(viper.GetInt("OIDC_STATE_EXPIRATION_MINUTES")) * time.Minute,
+	}
+}
+
+// parseGroupMapping membaca "group=id,group=id", urutannya dipertahankan karena mapping pertama yang cocok yang dipakai
+func parseGroupMapping(value string) []usecase.OidcGroupMapping {
+	var mappings []usecase.OidcGroupMapping
+	for _, item := range strings.Split(value, ",") {
+		index := strings.LastIndex(item, "=")
+		if index <= 0 {
+			continue
+		}
+		mappings = append(mappings, usecase.OidcGroupMapping{
+			Group: strings.TrimSpace(item[:index]),
+			Id:    strings.TrimSpace(item[index+1:]),
+		})
+	}
+	return mappings
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"time"
+	"todo-app/internal/usecase"
+
+	"github.com/spf13/viper"
+)
+
+// NewOutboxConfig loads how often the worker relays the outbox and how long published events
+// and the dedup records of consumed events are kept
+func NewOutboxConfig(viper *viper.Viper) *usecase.OutboxConfig {
+	viper.SetDefault("OUTBOX_POLL_INTERVAL_MILLISECONDS", 500)
+	viper.SetDefault("OUTBOX_BATCH_SIZE", 100)
+	viper.SetDefault("OUTBOX_RETENTION_HOURS", 168)
+	viper.SetDefault("PROCESSED_EVENT_RETENTION_HOURS", 336)
+
+	return &usecase.OutboxConfig{
+		PollInterval: time.Duration(viper.GetInt("OUTBOX_POLL_INTERVAL_MILLISECONDS")) * time.Millisecond,
+		BatchSize:    max(viper.GetInt("OUTBOX_BATCH_SIZE"), 1),
+		Retention:    time.Duration(viper.GetInt("OUTBOX_RETENTION_HOURS")) * time.Hour,
+		// Default dua kali retensi topic Kafka bawaan (7 hari)
+		ProcessedRetention: time.Duration(viper.GetInt("PROCESSED_EVENT_RETENTION_HOURS")) * time.Hour,
+	}
+}
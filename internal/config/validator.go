@@ -1,10 +1,30 @@
 package config
 
 import (
+	"todo-app/internal/util/helper"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/spf13/viper"
 )
 
 func NewValidator(viper *viper.Viper) *validator.Validate {
-	return validator.New()
+	validate := validator.New()
+	policy := NewPasswordPolicy(viper)
+
+	// Tag "password" membandingkan password dengan field Email dan Name di struct yang sama
+	if err := validate.RegisterValidation("password", policy.ValidateField); err != nil {
+		panic(err)
+	}
+
+	return validate
+}
+
+func NewPasswordPolicy(viper *viper.Viper) *helper.PasswordPolicy {
+	viper.SetDefault("PASSWORD_MIN_LENGTH", 8)
+	viper.SetDefault("PASSWORD_MIN_CLASSES", 3)
+
+	return &helper.PasswordPolicy{
+		MinLength:  viper.GetInt("PASSWORD_MIN_LENGTH"),
+		MinClasses: viper.GetInt("PASSWORD_MIN_CLASSES"),
+	}
 }
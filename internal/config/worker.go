@@ -0,0 +1,126 @@
+package config
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+	"todo-app/internal/gateway/mail"
+	"todo-app/internal/gateway/messaging"
+	"todo-app/internal/gateway/search"
+	"todo-app/internal/repository"
+	"todo-app/internal/usecase"
+
+	"github.com/IBM/sarama"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type WorkerConfig struct {
+	DB      *gorm.DB
+	Log     *logrus.Logger
+	Mailer  mail.Mailer
+	Indexer search.Indexer
+	// Producer mengirim message yang gagal ke retry topic dan dead-letter topic
+	Producer    sarama.SyncProducer
+	RetryPolicy messaging.RetryPolicy
+	Outbox      *usecase.OutboxConfig
+	// ConsumerGroup consumes every topic and retry topic, it is closed when the worker stops
+	ConsumerGroup sarama.ConsumerGroup
+}
+
+// BootstrapWorker relays the outbox and consumes every topic and its retry topics with one consumer group
+// until ctx is cancelled, then waits for the handlers to finish and closes the consumer group.
+// Consume errors are retried, only a consumer group that is closed underneath stops the whole worker.
+// Dead-letter topics are not consumed, they are replayed with cmd/replay.
+func BootstrapWorker(ctx context.Context, config *WorkerConfig) error {
+	outboxEventRepository := repository.NewOutboxEventRepository(config.Log)
+	processedEventRepository := repository.NewProcessedEventRepository(config.Log)
+	outboxRelayUseCase := usecase.NewOutboxRelayUseCase(config.DB, config.Log, config.Outbox, outboxEventRepository, processedEventRepository,
+		messaging.NewUserRegisteredProducer(config.Producer, config.Log),
+		messaging.NewRoleChangedProducer(config.Producer, config.Log),
+		messaging.NewCardMovedProducer(config.Producer, config.Log),
+		messaging.NewCardClosedProducer(config.Producer, config.Log),
+	)
+
+	// Setiap consumer mencatat event yang sudah diproses, event yang dikirim ulang setelah rebalance dilewati
+	userRegisteredConsumer := messaging.NewEventConsumer(messaging.TopicUserRegistered, config.DB, config.Log, processedEventRepository,
+		messaging.NewUserRegisteredConsumer(config.Log, config.Mailer).Handle)
+	roleChangedConsumer := messaging.NewEventConsumer(messaging.TopicRoleChanged, config.DB, config.Log, processedEventRepository,
+		messaging.NewRoleChangedConsumer(config.Log).Handle)
+	cardMovedConsumer := messaging.NewEventConsumer(messaging.TopicCardMoved, config.DB, config.Log, processedEventRepository,
+		messaging.NewCardMovedConsumer(config.Log, config.Indexer).Handle)
+	cardClosedConsumer := messaging.NewEventConsumer(messaging.TopicCardClosed, config.DB, config.Log, processedEventRepository,
+		messaging.NewCardClosedConsumer(config.Log, config.Indexer).Handle)
+
+	// Satu dispatcher per topic, consumer untuk versi event yang baru cukup ditambahkan ke dispatcher-nya
+	handlers := map[string]messaging.ConsumerHandler{
+		messaging.TopicUserRegistered: messaging.NewEventDispatcher(config.Log, userRegisteredConsumer).Consume,
+		messaging.TopicRoleChanged:    messaging.NewEventDispatcher(config.Log, roleChangedConsumer).Consume,
+		messaging.TopicCardMoved:      messaging.NewEventDispatcher(config.Log, cardMovedConsumer).Consume,
+		messaging.TopicCardClosed:     messaging.NewEventDispatcher(config.Log, cardClosedConsumer).Consume,
+	}
+	// Retry topic diproses oleh handler yang sama dengan topic asalnya
+	for topic, handler := range maps.Clone(handlers) {
+		for _, retryTopic := range messaging.RetryTopicsOf(topic, config.RetryPolicy) {
+			handlers[retryTopic] = handler
+		}
+	}
+	consumerHandler := messaging.NewConsumerGroupHandler(handlers, config.Producer, config.RetryPolicy, config.Log)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		runOutboxRelay(ctx, outboxRelayUseCase, config.Log)
+	}()
+
+	topics := consumerHandler.Topics()
+	config.Log.Infof("Consuming topics %v", topics)
+	err := messaging.ConsumeTopics(ctx, config.ConsumerGroup, topics, config.RetryPolicy.Backoff, config.Log, consumerHandler)
+	if err != nil {
+		cancel()
+	}
+
+	wg.Wait()
+	if err := config.ConsumerGroup.Close(); err != nil {
+		config.Log.WithError(err).Warn("Failed to close consumer group")
+	}
+	return err
+}
+
+// runOutboxRelay mengirim outbox setiap PollInterval, selama batch penuh batch berikutnya langsung dikirim
+func runOutboxRelay(ctx context.Context, relay *usecase.OutboxRelayUseCase, log *logrus.Logger) {
+	log.Info("Relaying outbox")
+	pollTicker := time.NewTicker(relay.Config.PollInterval)
+	defer pollTicker.Stop()
+	purgeTicker := time.NewTicker(time.Hour)
+	defer purgeTicker.Stop()
+
+	// Batch yang sedang dikirim diselesaikan saat shutdown supaya tidak dikirim ulang oleh worker berikutnya
+	relayCtx := context.WithoutCancel(ctx)
+	for {
+		for ctx.Err() == nil {
+			published, err := relay.Relay(relayCtx)
+			if err != nil || published < relay.Config.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-pollTicker.C:
+		case <-purgeTicker.C:
+			if total, err := relay.Purge(relayCtx); err == nil && total > 0 {
+				log.Infof("Purged %d published outbox events", total)
+			}
+			if total, err := relay.PurgeProcessed(relayCtx); err == nil && total > 0 {
+				log.Infof("Purged %d processed events", total)
+			}
+		}
+	}
+}
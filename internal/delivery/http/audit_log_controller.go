@@ -0,0 +1,102 @@
+package http
+
+import (
+	"bufio"
+	"math"
+	"time"
+	"todo-app/internal/model"
+	"todo-app/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type AuditLogController struct {
+	UseCase *usecase.AuditLogUseCase
+	Log     *logrus.Logger
+}
+
+func NewAuditLogController(useCase *usecase.AuditLogUseCase, log *logrus.Logger) *AuditLogController {
+	return &AuditLogController{
+		UseCase: useCase,
+		Log:     log,
+	}
+}
+
+func (c *AuditLogController) searchRequest(ctx *fiber.Ctx) (*model.SearchAuditLogRequest, error) {
+	request := &model.SearchAuditLogRequest{
+		ActorId:    ctx.Query("actor_id", ""),
+		Action:     ctx.Query("action", ""),
+		EntityType: ctx.Query("entity_type", ""),
+		EntityId:   ctx.Query("entity_id", ""),
+		RequestId:  ctx.Query("request_id", ""),
+		Page:       ctx.QueryInt("page", 1),
+		Size:       ctx.QueryInt("size", 10),
+	}
+
+	// from dan to dalam format RFC 3339, to bersifat eksklusif
+	for key, target := range map[string]**time.Time{"from": &request.From, "to": &request.To} {
+		if value := ctx.Query(key, ""); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, fiber.NewError(fiber.StatusBadRequest, key+" must be an RFC 3339 timestamp")
+			}
+			*target = &parsed
+		}
+	}
+
+	return request, nil
+}
+
+func (c *AuditLogController) List(ctx *fiber.Ctx) error {
+	request, err := c.searchRequest(ctx)
+	if err != nil {
+		c.Log.WithError(err).Error("error parsing search query")
+		return err
+	}
+
+	responses, total, err := c.UseCase.Search(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error searching audit logs")
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.AuditLogResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
+
+func (c *AuditLogController) Export(ctx *fiber.Ctx) error {
+	request, err := c.searchRequest(ctx)
+	if err != nil {
+		c.Log.WithError(err).Error("error parsing search query")
+		return err
+	}
+
+	write, err := c.UseCase.Export(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error exporting audit logs")
+		return err
+	}
+
+	ctx.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	ctx.Set(fiber.HeaderContentDisposition, `attachment; filename="audit-log.csv"`)
+
+	// Body ditulis bertahap setelah handler selesai, error di tengah jalan hanya bisa dicatat di log
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := write(w); err != nil {
+			c.Log.WithError(err).Error("error streaming audit log export")
+		}
+		_ = w.Flush()
+	})
+
+	return nil
+}
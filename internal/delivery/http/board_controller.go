@@ -0,0 +1,180 @@
+package http
+
+import (
+	"math"
+	"todo-app/internal/delivery/http/middleware"
+	"todo-app/internal/model"
+	"todo-app/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type BoardController struct {
+	UseCase *usecase.BoardUseCase
+	Log     *logrus.Logger
+}
+
+func NewBoardController(useCase *usecase.BoardUseCase, log *logrus.Logger) *BoardController {
+	return &BoardController{
+		UseCase: useCase,
+		Log:     log,
+	}
+}
+
+func (c *BoardController) Create(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := new(model.CreateBoardRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.ErrBadRequest
+	}
+
+	request.UserId = auth.ID
+	request.ProjectId = ctx.Params("projectId")
+
+	response, err := c.UseCase.Create(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error creating board")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.BoardResponse]{Data: response})
+}
+
+func (c *BoardController) List(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := &model.SearchBoardRequest{
+		UserId:    auth.ID,
+		ProjectId: ctx.Params("projectId"),
+		Name:      ctx.Query("name", ""),
+		Page:      ctx.QueryInt("page", 1),
+		Size:      ctx.QueryInt("size", 10),
+	}
+
+	responses, total, err := c.UseCase.Search(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error searching board")
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.BoardResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
+
+func (c *BoardController) Get(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := &model.GetBoardRequest{
+		UserId:    auth.ID,
+		ProjectId: ctx.Params("projectId"),
+		ID:        ctx.Params("boardId"),
+	}
+
+	response, err := c.UseCase.Get(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting board")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.BoardResponse]{Data: response})
+}
+
+func (c *BoardController) Update(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := new(model.UpdateBoardRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.ErrBadRequest
+	}
+
+	request.UserId = auth.ID
+	request.ProjectId = ctx.Params("projectId")
+	request.ID = ctx.Params("boardId")
+
+	response, err := c.UseCase.Update(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error updating board")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.BoardResponse]{Data: response})
+}
+
+func (c *BoardController) SoftDelete(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	req := &model.GetBoardRequest{
+		UserId:    auth.ID,
+		ProjectId: ctx.Params("projectId"),
+		ID:        ctx.Params("boardId"),
+	}
+
+	resp, err := c.UseCase.SoftDelete(ctx.UserContext(), req)
+	if err != nil {
+		c.Log.WithError(err).Error("error soft deleting board")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.BoardResponse]{Data: resp})
+}
+
+func (c *BoardController) RecycleBin(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := &model.SearchBoardRequest{
+		UserId:    auth.ID,
+		ProjectId: ctx.Params("projectId"),
+		Name:      ctx.Query("name", ""),
+		Page:      ctx.QueryInt("page", 1),
+		Size:      ctx.QueryInt("size", 10),
+	}
+
+	responses, total, err := c.UseCase.RecycleBin(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting trashed board")
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.BoardResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
+
+func (c *BoardController) Restore(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	req := &model.GetBoardRequest{
+		UserId:    auth.ID,
+		ProjectId: ctx.Params("projectId"),
+		ID:        ctx.Params("boardId"),
+	}
+
+	resp, err := c.UseCase.Restore(ctx.UserContext(), req)
+	if err != nil {
+		c.Log.WithError(err).Error("error restoring board")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.BoardResponse]{Data: resp})
+}
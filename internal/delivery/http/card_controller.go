@@ -0,0 +1,192 @@
+package http
+
+import (
+	"math"
+	"todo-app/internal/delivery/http/middleware"
+	"todo-app/internal/model"
+	"todo-app/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type CardController struct {
+	UseCase *usecase.CardUseCase
+	Log     *logrus.Logger
+}
+
+func NewCardController(useCase *usecase.CardUseCase, log *logrus.Logger) *CardController {
+	return &CardController{
+		UseCase: useCase,
+		Log:     log,
+	}
+}
+
+func (c *CardController) Create(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := new(model.CreateCardRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.ErrBadRequest
+	}
+
+	request.UserId = auth.ID
+	request.ProjectId = ctx.Params("projectId")
+	request.BoardId = ctx.Params("boardId")
+
+	response, err := c.UseCase.Create(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error creating card")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.CardResponse]{Data: response})
+}
+
+func (c *CardController) List(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := &model.SearchCardRequest{
+		UserId:    auth.ID,
+		ProjectId: ctx.Params("projectId"),
+		BoardId:   ctx.Params("boardId"),
+		Name:      ctx.Query("name", ""),
+		Page:      ctx.QueryInt("page", 1),
+		Size:      ctx.QueryInt("size", 10),
+	}
+
+	responses, total, err := c.UseCase.Search(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error searching card")
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.CardResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
+
+func (c *CardController) Get(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := &model.GetCardRequest{
+		UserId:    auth.ID,
+		ProjectId: ctx.Params("projectId"),
+		ID:        ctx.Params("cardId"),
+	}
+
+	response, err := c.UseCase.Get(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting card")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.CardResponse]{Data: response})
+}
+
+func (c *CardController) Move(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := new(model.MoveCardRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.ErrBadRequest
+	}
+
+	request.UserId = auth.ID
+	request.ProjectId = ctx.Params("projectId")
+	request.ID = ctx.Params("cardId")
+
+	response, err := c.UseCase.Move(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error moving card")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.CardResponse]{Data: response})
+}
+
+func (c *CardController) Assign(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := new(model.AssignCardRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.ErrBadRequest
+	}
+
+	request.UserId = auth.ID
+	request.ProjectId = ctx.Params("projectId")
+	request.ID = ctx.Params("cardId")
+
+	response, err := c.UseCase.Assign(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error assigning card")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.CardResponse]{Data: response})
+}
+
+func (c *CardController) Close(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	req := &model.GetCardRequest{
+		UserId:    auth.ID,
+		ProjectId: ctx.Params("projectId"),
+		ID:        ctx.Params("cardId"),
+	}
+
+	resp, err := c.UseCase.Close(ctx.UserContext(), req)
+	if err != nil {
+		c.Log.WithError(err).Error("error closing card")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.CardResponse]{Data: resp})
+}
+
+func (c *CardController) Reopen(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	req := &model.GetCardRequest{
+		UserId:    auth.ID,
+		ProjectId: ctx.Params("projectId"),
+		ID:        ctx.Params("cardId"),
+	}
+
+	resp, err := c.UseCase.Reopen(ctx.UserContext(), req)
+	if err != nil {
+		c.Log.WithError(err).Error("error reopening card")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.CardResponse]{Data: resp})
+}
+
+func (c *CardController) SoftDelete(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	req := &model.GetCardRequest{
+		UserId:    auth.ID,
+		ProjectId: ctx.Params("projectId"),
+		ID:        ctx.Params("cardId"),
+	}
+
+	resp, err := c.UseCase.SoftDelete(ctx.UserContext(), req)
+	if err != nil {
+		c.Log.WithError(err).Error("error soft deleting card")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.CardResponse]{Data: resp})
+}
@@ -0,0 +1,183 @@
+package http
+
+import (
+	"math"
+	"todo-app/internal/model"
+	"todo-app/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type DepartmentController struct {
+	UseCase *usecase.DepartmentUseCase
+	Log     *logrus.Logger
+}
+
+func NewDepartmentController(useCase *usecase.DepartmentUseCase, log *logrus.Logger) *DepartmentController {
+	return &DepartmentController{
+		UseCase: useCase,
+		Log:     log,
+	}
+}
+
+func (c *DepartmentController) Create(ctx *fiber.Ctx) error {
+
+	request := new(model.CreateDepartmentRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.ErrBadRequest
+	}
+
+	response, err := c.UseCase.Create(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error creating department")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.DepartmentResponse]{Data: response})
+}
+
+func (c *DepartmentController) List(ctx *fiber.Ctx) error {
+
+	request := &model.SearchDepartmentRequest{
+		Name: ctx.Query("name", ""),
+		Page: ctx.QueryInt("page", 1),
+		Size: ctx.QueryInt("size", 10),
+	}
+
+	responses, total, err := c.UseCase.Search(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error searching department")
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.DepartmentResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
+
+func (c *DepartmentController) Get(ctx *fiber.Ctx) error {
+
+	request := &model.GetDepartmentRequest{
+		ID: ctx.Params("departmentId"),
+	}
+
+	response, err := c.UseCase.Get(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting department")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.DepartmentResponse]{Data: response})
+}
+
+func (c *DepartmentController) Update(ctx *fiber.Ctx) error {
+	request := new(model.UpdateDepartmentRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.ErrBadRequest
+	}
+
+	request.ID = ctx.Params("departmentId")
+
+	response, err := c.UseCase.Update(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error updating department")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.DepartmentResponse]{Data: response})
+}
+
+func (c *DepartmentController) SoftDelete(ctx *fiber.Ctx) error {
+	req := &model.GetDepartmentRequest{
+		ID: ctx.Params("departmentId"),
+	}
+
+	resp, err := c.UseCase.SoftDelete(ctx.UserContext(), req)
+	if err != nil {
+		c.Log.WithError(err).Error("error soft deleting department")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.DepartmentResponse]{Data: resp})
+}
+
+func (c *DepartmentController) RecycleBin(ctx *fiber.Ctx) error {
+	request := &model.SearchDepartmentRequest{
+		Name: ctx.Query("name", ""),
+		Page: ctx.QueryInt("page", 1),
+		Size: ctx.QueryInt("size", 10),
+	}
+
+	responses, total, err := c.UseCase.RecycleBin(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.DepartmentResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
+
+func (c *DepartmentController) Restore(ctx *fiber.Ctx) error {
+	req := &model.GetDepartmentRequest{
+		ID: ctx.Params("departmentId"),
+	}
+
+	resp, err := c.UseCase.Restore(ctx.UserContext(), req)
+	if err != nil {
+		c.Log.WithError(err).Error("error restoring department")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.DepartmentResponse]{Data: resp})
+}
+
+func (c *DepartmentController) ForceDelete(ctx *fiber.Ctx) error {
+	req := &model.DeleteDepartmentRequest{
+		ID: ctx.Params("departmentId"),
+	}
+
+	if err := c.UseCase.ForceDelete(ctx.UserContext(), req); err != nil {
+		c.Log.WithError(err).Error("error force deleting department")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
+
+func (c *DepartmentController) MoveUsers(ctx *fiber.Ctx) error {
+	request := new(model.MoveDepartmentUsersRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.ErrBadRequest
+	}
+
+	request.ID = ctx.Params("departmentId")
+
+	responses, err := c.UseCase.MoveUsers(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error moving users to department")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.UserResponse]{Data: responses})
+}
@@ -0,0 +1,28 @@
+package http
+
+import (
+	"todo-app/internal/model/converter"
+	"todo-app/internal/util/helper"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// JWKSController publishes the public keys of the access tokens so other services can verify them
+type JWKSController struct {
+	Signer *helper.JWTSigner
+	Log    *logrus.Logger
+}
+
+func NewJWKSController(signer *helper.JWTSigner, log *logrus.Logger) *JWKSController {
+	return &JWKSController{
+		Signer: signer,
+		Log:    log,
+	}
+}
+
+// Get returns the key set as a plain JWKS document (not wrapped in WebResponse), as expected by JWT libraries
+func (c *JWKSController) Get(ctx *fiber.Ctx) error {
+	ctx.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return ctx.JSON(converter.SigningKeysToJWKS(c.Signer.Keys()))
+}
@@ -0,0 +1,98 @@
+package http
+
+import (
+	"todo-app/internal/delivery/http/middleware"
+	"todo-app/internal/model"
+	"todo-app/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type MfaController struct {
+	UseCase *usecase.MfaUseCase
+	Log     *logrus.Logger
+}
+
+func NewMfaController(useCase *usecase.MfaUseCase, log *logrus.Logger) *MfaController {
+	return &MfaController{
+		UseCase: useCase,
+		Log:     log,
+	}
+}
+
+func (c *MfaController) Enroll(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := &model.EnrollMfaRequest{
+		UserId: auth.ID,
+	}
+
+	response, err := c.UseCase.Enroll(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error enrolling mfa")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.MfaEnrollResponse]{Data: response})
+}
+
+func (c *MfaController) Confirm(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := new(model.ConfirmMfaRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.ErrBadRequest
+	}
+
+	request.UserId = auth.ID
+
+	response, err := c.UseCase.Confirm(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error confirming mfa")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.MfaRecoveryCodesResponse]{Data: response})
+}
+
+func (c *MfaController) RegenerateRecoveryCodes(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := new(model.RegenerateRecoveryCodesRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.ErrBadRequest
+	}
+
+	request.UserId = auth.ID
+
+	response, err := c.UseCase.RegenerateRecoveryCodes(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error regenerating recovery codes")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.MfaRecoveryCodesResponse]{Data: response})
+}
+
+func (c *MfaController) Disable(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := new(model.DisableMfaRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.ErrBadRequest
+	}
+
+	request.UserId = auth.ID
+
+	response, err := c.UseCase.Disable(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error disabling mfa")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[bool]{Data: response})
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"todo-app/internal/util/helper"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// NewAuditContext puts the request id and client IP into the user context for the audit log.
+// An incoming X-Request-ID is kept so a request can be traced across services; NewAuth adds the actor later.
+func NewAuditContext() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		requestId := ctx.Get(fiber.HeaderXRequestID)
+		if requestId == "" || len(requestId) > 100 {
+			requestId = uuid.NewString()
+		}
+		ctx.Set(fiber.HeaderXRequestID, requestId)
+
+		ctx.SetUserContext(helper.WithAuditContext(ctx.UserContext(), &helper.AuditContext{
+			IpAddress: ctx.IP(),
+			RequestId: requestId,
+		}))
+
+		return ctx.Next()
+	}
+}
@@ -1,50 +1,85 @@
 package middleware
 
 import (
+	"slices"
 	"strings"
 
 	"todo-app/internal/model"
 	"todo-app/internal/usecase"
+	"todo-app/internal/util/helper"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func NewAuth(userUseCase *usecase.UserUseCase) fiber.Handler {
-	return func(ctx *fiber.Ctx) error {
-		authHeader := ctx.Get("Authorization")
-		if authHeader == "" {
-			userUseCase.Log.Warn("Missing Authorization header")
-			return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Missing Authorization header",
-			})
-		}
+// NewAuth returns a factory for route level middleware that accepts either a session JWT or a
+// personal access token (prefix pat_) as bearer token. A personal access token is denied unless
+// the route declares a scope the token carries, so a route without scopes needs a login session.
+func NewAuth(userUseCase *usecase.UserUseCase, personalAccessTokenUseCase *usecase.PersonalAccessTokenUseCase) func(scopes ...string) fiber.Handler {
+	return func(scopes ...string) fiber.Handler {
+		return func(ctx *fiber.Ctx) error {
+			authHeader := ctx.Get("Authorization")
+			if authHeader == "" {
+				userUseCase.Log.Warn("Missing Authorization header")
+				return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"status":  "error",
+					"message": "Missing Authorization header",
+				})
+			}
 
-		// Format: "Bearer <token>"
-		parts := strings.SplitN(authHeader, " ", 2)
-		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
-			userUseCase.Log.Warnf("Invalid Authorization format: %s", authHeader)
-			return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Invalid Authorization format. Use Bearer <token>",
-			})
-		}
-		token := parts[1]
-
-		// Verifikasi token lewat usecase
-		request := &model.VerifyUserRequest{Token: token}
-		auth, err := userUseCase.Verify(ctx.UserContext(), request)
-		if err != nil {
-			userUseCase.Log.Warnf("Failed verify user token: %+v", err)
-			return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Invalid or expired token",
-			})
-		}
+			// Format: "Bearer <token>"
+			parts := strings.SplitN(authHeader, " ", 2)
+			if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+				userUseCase.Log.Warnf("Invalid Authorization format: %s", authHeader)
+				return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"status":  "error",
+					"message": "Invalid Authorization format. Use Bearer <token>",
+				})
+			}
+			token := parts[1]
+
+			// Verifikasi token lewat usecase
+			var auth *model.Auth
+			var err error
+			if strings.HasPrefix(token, helper.PersonalAccessTokenPrefix) {
+				auth, err = personalAccessTokenUseCase.Verify(ctx.UserContext(), &model.VerifyPersonalAccessTokenRequest{Token: token})
+			} else {
+				auth, err = userUseCase.Verify(ctx.UserContext(), &model.VerifyUserRequest{Token: token})
+			}
+			if err != nil {
+				userUseCase.Log.Warnf("Failed verify user token: %+v", err)
+				return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"status":  "error",
+					"message": "Invalid or expired token",
+				})
+			}
 
-		// Simpan user ke context
-		ctx.Locals("auth", auth)
-		return ctx.Next()
+			// Personal access token ditolak kecuali route mendeklarasikan scope yang dibawa token
+			if auth.PersonalAccessToken && !slices.ContainsFunc(scopes, func(scope string) bool {
+				return slices.Contains(auth.Scopes, scope)
+			}) {
+				if len(scopes) == 0 {
+					return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
+						"status":  "error",
+						"message": "This endpoint requires a login session",
+					})
+				}
+				userUseCase.Log.Warnf("Personal access token %s is missing scope %v", auth.TokenId, scopes)
+				return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"status":  "error",
+					"message": "Token scope does not allow this resource",
+				})
+			}
+
+			// Simpan user ke context
+			ctx.Locals("auth", auth)
+
+			// Actor untuk audit log
+			auditContext := *helper.GetAuditContext(ctx.UserContext())
+			auditContext.ActorId = auth.ID
+			ctx.SetUserContext(helper.WithAuditContext(ctx.UserContext(), &auditContext))
+
+			return ctx.Next()
+		}
 	}
 }
 
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// NewMfaEnrollment blocks the route while the role of the logged in user requires MFA
+// and the user has not finished enrollment. It must run after NewAuth.
+func NewMfaEnrollment() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		auth := GetUser(ctx)
+		if auth != nil && auth.MfaPending {
+			return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"status":  "error",
+				"message": "MFA enrollment is required for your role",
+			})
+		}
+
+		return ctx.Next()
+	}
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"slices"
+	"todo-app/internal/model"
+	"todo-app/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// NewPermission returns a factory for route level middleware that only lets the request through
+// when the role of the logged in user has every given permission. It must run after NewAuth.
+func NewPermission(permissionUseCase *usecase.PermissionUseCase) func(permissions ...string) fiber.Handler {
+	return func(permissions ...string) fiber.Handler {
+		return func(ctx *fiber.Ctx) error {
+			auth := GetUser(ctx)
+			if auth == nil || auth.RoleId == "" {
+				permissionUseCase.Log.Warn("Missing role for permission check")
+				return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"status":  "error",
+					"message": "Forbidden",
+				})
+			}
+
+			// Personal access token hanya boleh memakai permission yang ada di scope-nya
+			if auth.PersonalAccessToken {
+				for _, permission := range permissions {
+					if !slices.Contains(auth.Scopes, permission) {
+						permissionUseCase.Log.Warnf("Personal access token %s is missing scope %s", auth.TokenId, permission)
+						return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
+							"status":  "error",
+							"message": "Token scope does not allow this resource",
+						})
+					}
+				}
+			}
+
+			request := &model.CheckPermissionRequest{
+				RoleId:      auth.RoleId,
+				Permissions: permissions,
+			}
+			allowed, err := permissionUseCase.Check(ctx.UserContext(), request)
+			if err != nil {
+				permissionUseCase.Log.Warnf("Failed check permission: %+v", err)
+				return err
+			}
+
+			if !allowed {
+				permissionUseCase.Log.Warnf("User %s is missing permission %v", auth.ID, permissions)
+				return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"status":  "error",
+					"message": "You do not have permission to access this resource",
+				})
+			}
+
+			return ctx.Next()
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package http
+
+import (
+	"todo-app/internal/model"
+	"todo-app/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type OidcController struct {
+	UseCase *usecase.OidcUseCase
+	Log     *logrus.Logger
+}
+
+func NewOidcController(useCase *usecase.OidcUseCase, log *logrus.Logger) *OidcController {
+	return &OidcController{
+		UseCase: useCase,
+		Log:     log,
+	}
+}
+
+func (c *OidcController) Authorize(ctx *fiber.Ctx) error {
+	response, err := c.UseCase.Authorize(ctx.UserContext())
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to start oidc login")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.OidcAuthorizeResponse]{Data: response})
+}
+
+func (c *OidcController) Callback(ctx *fiber.Ctx) error {
+	request := new(model.OidcCallbackRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return fiber.ErrBadRequest
+	}
+
+	request.IpAddress = ctx.IP()
+	request.UserAgent = userAgent(ctx)
+
+	response, err := c.UseCase.Callback(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to login user with oidc")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.UserResponse]{Data: response})
+}
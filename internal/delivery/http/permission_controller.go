@@ -0,0 +1,57 @@
+package http
+
+import (
+	"todo-app/internal/model"
+	"todo-app/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type PermissionController struct {
+	UseCase *usecase.PermissionUseCase
+	Log     *logrus.Logger
+}
+
+func NewPermissionController(useCase *usecase.PermissionUseCase, log *logrus.Logger) *PermissionController {
+	return &PermissionController{
+		UseCase: useCase,
+		Log:     log,
+	}
+}
+
+func (c *PermissionController) List(ctx *fiber.Ctx) error {
+	return ctx.JSON(model.WebResponse[[]string]{Data: model.Permissions})
+}
+
+func (c *PermissionController) Get(ctx *fiber.Ctx) error {
+	request := &model.GetRolePermissionRequest{
+		RoleId: ctx.Params("roleId"),
+	}
+
+	response, err := c.UseCase.Get(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting role permissions")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.RolePermissionResponse]{Data: response})
+}
+
+func (c *PermissionController) Update(ctx *fiber.Ctx) error {
+	request := new(model.UpdateRolePermissionRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.ErrBadRequest
+	}
+
+	request.RoleId = ctx.Params("roleId")
+
+	response, err := c.UseCase.Update(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error updating role permissions")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.RolePermissionResponse]{Data: response})
+}
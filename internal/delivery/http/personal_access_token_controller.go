@@ -0,0 +1,76 @@
+package http
+
+import (
+	"todo-app/internal/delivery/http/middleware"
+	"todo-app/internal/model"
+	"todo-app/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type PersonalAccessTokenController struct {
+	UseCase *usecase.PersonalAccessTokenUseCase
+	Log     *logrus.Logger
+}
+
+func NewPersonalAccessTokenController(useCase *usecase.PersonalAccessTokenUseCase, log *logrus.Logger) *PersonalAccessTokenController {
+	return &PersonalAccessTokenController{
+		UseCase: useCase,
+		Log:     log,
+	}
+}
+
+func (c *PersonalAccessTokenController) Create(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := new(model.CreatePersonalAccessTokenRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return fiber.ErrBadRequest
+	}
+
+	request.UserId = auth.ID
+	request.RoleId = auth.RoleId
+
+	response, err := c.UseCase.Create(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to create personal access token")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.PersonalAccessTokenResponse]{Data: response})
+}
+
+func (c *PersonalAccessTokenController) List(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := &model.ListPersonalAccessTokenRequest{
+		UserId: auth.ID,
+	}
+
+	responses, err := c.UseCase.List(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to list personal access tokens")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.PersonalAccessTokenResponse]{Data: responses})
+}
+
+func (c *PersonalAccessTokenController) Revoke(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := &model.RevokePersonalAccessTokenRequest{
+		UserId: auth.ID,
+		ID:     ctx.Params("tokenId"),
+	}
+
+	response, err := c.UseCase.Revoke(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to revoke personal access token")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[bool]{Data: response})
+}
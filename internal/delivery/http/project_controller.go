@@ -0,0 +1,170 @@
+package http
+
+import (
+	"math"
+	"todo-app/internal/delivery/http/middleware"
+	"todo-app/internal/model"
+	"todo-app/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type ProjectController struct {
+	UseCase *usecase.ProjectUseCase
+	Log     *logrus.Logger
+}
+
+func NewProjectController(useCase *usecase.ProjectUseCase, log *logrus.Logger) *ProjectController {
+	return &ProjectController{
+		UseCase: useCase,
+		Log:     log,
+	}
+}
+
+func (c *ProjectController) Create(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := new(model.CreateProjectRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.ErrBadRequest
+	}
+
+	request.UserId = auth.ID
+
+	response, err := c.UseCase.Create(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error creating project")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.ProjectResponse]{Data: response})
+}
+
+func (c *ProjectController) List(ctx *fiber.Ctx) error {
+	request := &model.SearchProjectRequest{
+		Name:         ctx.Query("name", ""),
+		DepartmentId: ctx.Query("department_id", ""),
+		Page:         ctx.QueryInt("page", 1),
+		Size:         ctx.QueryInt("size", 10),
+	}
+
+	responses, total, err := c.UseCase.Search(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error searching project")
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.ProjectResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
+
+func (c *ProjectController) Get(ctx *fiber.Ctx) error {
+	request := &model.GetProjectRequest{
+		ID: ctx.Params("projectId"),
+	}
+
+	response, err := c.UseCase.Get(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting project")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.ProjectResponse]{Data: response})
+}
+
+func (c *ProjectController) Update(ctx *fiber.Ctx) error {
+	request := new(model.UpdateProjectRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.ErrBadRequest
+	}
+
+	request.ID = ctx.Params("projectId")
+
+	response, err := c.UseCase.Update(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error updating project")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.ProjectResponse]{Data: response})
+}
+
+func (c *ProjectController) SoftDelete(ctx *fiber.Ctx) error {
+	req := &model.GetProjectRequest{
+		ID: ctx.Params("projectId"),
+	}
+
+	resp, err := c.UseCase.SoftDelete(ctx.UserContext(), req)
+	if err != nil {
+		c.Log.WithError(err).Error("error soft deleting project")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.ProjectResponse]{Data: resp})
+}
+
+func (c *ProjectController) RecycleBin(ctx *fiber.Ctx) error {
+	request := &model.SearchProjectRequest{
+		Name:         ctx.Query("name", ""),
+		DepartmentId: ctx.Query("department_id", ""),
+		Page:         ctx.QueryInt("page", 1),
+		Size:         ctx.QueryInt("size", 10),
+	}
+
+	responses, total, err := c.UseCase.RecycleBin(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting trashed project")
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.ProjectResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
+
+func (c *ProjectController) Restore(ctx *fiber.Ctx) error {
+	req := &model.GetProjectRequest{
+		ID: ctx.Params("projectId"),
+	}
+
+	resp, err := c.UseCase.Restore(ctx.UserContext(), req)
+	if err != nil {
+		c.Log.WithError(err).Error("error restoring project")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.ProjectResponse]{Data: resp})
+}
+
+func (c *ProjectController) ForceDelete(ctx *fiber.Ctx) error {
+	req := &model.DeleteProjectRequest{
+		ID: ctx.Params("projectId"),
+	}
+
+	if err := c.UseCase.ForceDelete(ctx.UserContext(), req); err != nil {
+		c.Log.WithError(err).Error("error force deleting project")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
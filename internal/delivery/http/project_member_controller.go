@@ -0,0 +1,113 @@
+package http
+
+import (
+	"math"
+	"todo-app/internal/delivery/http/middleware"
+	"todo-app/internal/model"
+	"todo-app/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type ProjectMemberController struct {
+	UseCase *usecase.ProjectMemberUseCase
+	Log     *logrus.Logger
+}
+
+func NewProjectMemberController(useCase *usecase.ProjectMemberUseCase, log *logrus.Logger) *ProjectMemberController {
+	return &ProjectMemberController{
+		UseCase: useCase,
+		Log:     log,
+	}
+}
+
+func (c *ProjectMemberController) Add(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := new(model.AddProjectMemberRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.ErrBadRequest
+	}
+
+	request.ActorId = auth.ID
+	request.ProjectId = ctx.Params("projectId")
+
+	response, err := c.UseCase.Add(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error adding project member")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.ProjectMemberResponse]{Data: response})
+}
+
+func (c *ProjectMemberController) List(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := &model.SearchProjectMemberRequest{
+		ActorId:   auth.ID,
+		ProjectId: ctx.Params("projectId"),
+		Role:      ctx.Query("role", ""),
+		Page:      ctx.QueryInt("page", 1),
+		Size:      ctx.QueryInt("size", 10),
+	}
+
+	responses, total, err := c.UseCase.Search(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error searching project member")
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.ProjectMemberResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
+
+func (c *ProjectMemberController) Update(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := new(model.UpdateProjectMemberRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.ErrBadRequest
+	}
+
+	request.ActorId = auth.ID
+	request.ProjectId = ctx.Params("projectId")
+	request.UserId = ctx.Params("userId")
+
+	response, err := c.UseCase.Update(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error updating project member")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.ProjectMemberResponse]{Data: response})
+}
+
+func (c *ProjectMemberController) Remove(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	req := &model.RemoveProjectMemberRequest{
+		ActorId:   auth.ID,
+		ProjectId: ctx.Params("projectId"),
+		UserId:    ctx.Params("userId"),
+	}
+
+	if err := c.UseCase.Remove(ctx.UserContext(), req); err != nil {
+		c.Log.WithError(err).Error("error removing project member")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
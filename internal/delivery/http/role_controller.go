@@ -65,6 +65,24 @@ func (c *RoleController) List(ctx *fiber.Ctx) error {
 	})
 }
 
+func (c *RoleController) UpdateMfa(ctx *fiber.Ctx) error {
+	request := new(model.UpdateRoleMfaRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.ErrBadRequest
+	}
+
+	request.ID = ctx.Params("roleId")
+
+	response, err := c.UseCase.UpdateMfa(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error updating role mfa requirement")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.RoleResponse]{Data: response})
+}
+
 func (c *RoleController) Get(ctx *fiber.Ctx) error {
 
 	request := &model.GetRoleRequest{
@@ -2,41 +2,139 @@ package route
 
 import (
 	"todo-app/internal/delivery/http"
+	"todo-app/internal/model"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 type RouteConfig struct {
-	App            *fiber.App
-	UserController *http.UserController
-	RoleController *http.RoleController
-	AuthMiddleware fiber.Handler
+	App                  *fiber.App
+	UserController       *http.UserController
+	SessionController    *http.SessionController
+	MfaController        *http.MfaController
+	OidcController       *http.OidcController
+	JWKSController       *http.JWKSController
+	UserAdminController  *http.UserAdminController
+	TokenController      *http.PersonalAccessTokenController
+	AuditLogController   *http.AuditLogController
+	RoleController       *http.RoleController
+	PermissionController *http.PermissionController
+	ProjectController    *http.ProjectController
+	BoardController      *http.BoardController
+	CardController       *http.CardController
+	MemberController     *http.ProjectMemberController
+	DepartmentController *http.DepartmentController
+	AuditMiddleware      fiber.Handler
+	AuthMiddleware       func(scopes ...string) fiber.Handler
+	MfaMiddleware        fiber.Handler
+	RequirePermission    func(permissions ...string) fiber.Handler
 }
 
 func (c *RouteConfig) Setup() {
+	c.App.Use(c.AuditMiddleware)
 	c.SetupGuestRoute()
 	c.SetupAuthRoute()
 }
 
 func (c *RouteConfig) SetupGuestRoute() {
+	c.App.Get("/.well-known/jwks.json", c.JWKSController.Get)
 	c.App.Post("/api/users", c.UserController.Register)
 	c.App.Post("/api/auth/login", c.UserController.Login)
+	c.App.Post("/api/auth/login/mfa", c.UserController.LoginMfa)
+	c.App.Post("/api/auth/refresh", c.UserController.Refresh)
+	c.App.Post("/api/auth/verify-email", c.UserController.VerifyEmail)
+	c.App.Post("/api/auth/verify-email/resend", c.UserController.ResendVerificationEmail)
+	c.App.Post("/api/auth/forgot-password", c.UserController.ForgotPassword)
+	c.App.Post("/api/auth/reset-password", c.UserController.ResetPassword)
+	c.App.Get("/api/auth/oidc/authorize", c.OidcController.Authorize)
+	c.App.Post("/api/auth/oidc/callback", c.OidcController.Callback)
 }
 
 func (c *RouteConfig) SetupAuthRoute() {
-	c.App.Use(c.AuthMiddleware)
-	c.App.Delete("/api/auth/logout", c.UserController.Logout)
-	c.App.Patch("/api/profile/update", c.UserController.Update)
-	c.App.Get("/api/profile", c.UserController.Current)
-	c.App.Post("/api/auth/refresh", c.UserController.Refresh)
+	// Personal access token hanya diterima di route yang mendeklarasikan scope yang dibawa token,
+	// route tanpa scope hanya bisa diakses dengan login session
+	c.App.Delete("/api/auth/logout", c.AuthMiddleware(), c.UserController.Logout)
+	c.App.Get("/api/auth/sessions", c.AuthMiddleware(), c.SessionController.List)
+	c.App.Delete("/api/auth/sessions", c.AuthMiddleware(), c.SessionController.RevokeAll)
+	c.App.Delete("/api/auth/sessions/:sessionId", c.AuthMiddleware(), c.SessionController.Revoke)
+	c.App.Post("/api/auth/mfa/enroll", c.AuthMiddleware(), c.MfaController.Enroll)
+	c.App.Post("/api/auth/mfa/confirm", c.AuthMiddleware(), c.MfaController.Confirm)
+	c.App.Post("/api/auth/mfa/recovery-codes", c.AuthMiddleware(), c.MfaController.RegenerateRecoveryCodes)
+	c.App.Delete("/api/auth/mfa", c.AuthMiddleware(), c.MfaController.Disable)
+
+	// Route di bawah ini tertutup untuk user yang rolenya wajib MFA tapi belum enroll
+	c.App.Patch("/api/profile/update", c.AuthMiddleware(), c.MfaMiddleware, c.UserController.Update)
+	c.App.Get("/api/profile", c.AuthMiddleware(model.ScopeProfileRead), c.MfaMiddleware, c.UserController.Current)
+
+	c.App.Get("/api/auth/tokens", c.AuthMiddleware(), c.MfaMiddleware, c.TokenController.List)
+	c.App.Post("/api/auth/tokens", c.AuthMiddleware(), c.MfaMiddleware, c.TokenController.Create)
+	c.App.Delete("/api/auth/tokens/:tokenId", c.AuthMiddleware(), c.MfaMiddleware, c.TokenController.Revoke)
+
+	c.App.Put("/api/users/unlock/:userId", c.AuthMiddleware(model.PermissionUsersAdmin), c.MfaMiddleware, c.RequirePermission(model.PermissionUsersAdmin), c.UserController.Unlock)
+
+	c.App.Get("/api/admin/users", c.AuthMiddleware(model.PermissionUsersAdmin), c.MfaMiddleware, c.RequirePermission(model.PermissionUsersAdmin), c.UserAdminController.List)
+	c.App.Put("/api/admin/users/update/:userId", c.AuthMiddleware(model.PermissionUsersAdmin), c.MfaMiddleware, c.RequirePermission(model.PermissionUsersAdmin), c.UserAdminController.Update)
+	c.App.Get("/api/admin/users/view/:userId", c.AuthMiddleware(model.PermissionUsersAdmin), c.MfaMiddleware, c.RequirePermission(model.PermissionUsersAdmin), c.UserAdminController.Get)
+	c.App.Put("/api/admin/users/delete/:userId", c.AuthMiddleware(model.PermissionUsersAdmin), c.MfaMiddleware, c.RequirePermission(model.PermissionUsersAdmin), c.UserAdminController.SoftDelete)
+	c.App.Get("/api/admin/users/trash", c.AuthMiddleware(model.PermissionUsersAdmin), c.MfaMiddleware, c.RequirePermission(model.PermissionUsersAdmin), c.UserAdminController.RecycleBin)
+	c.App.Put("/api/admin/users/restore/:userId", c.AuthMiddleware(model.PermissionUsersAdmin), c.MfaMiddleware, c.RequirePermission(model.PermissionUsersAdmin), c.UserAdminController.Restore)
+	c.App.Delete("/api/admin/users/force/:userId", c.AuthMiddleware(model.PermissionUsersAdmin), c.MfaMiddleware, c.RequirePermission(model.PermissionUsersAdmin), c.UserAdminController.ForceDelete)
+
+	c.App.Get("/api/admin/audit", c.AuthMiddleware(model.PermissionAuditRead), c.MfaMiddleware, c.RequirePermission(model.PermissionAuditRead), c.AuditLogController.List)
+	c.App.Get("/api/admin/audit/export", c.AuthMiddleware(model.PermissionAuditRead), c.MfaMiddleware, c.RequirePermission(model.PermissionAuditRead), c.AuditLogController.Export)
+
+	c.App.Get("/api/permissions", c.AuthMiddleware(model.PermissionRolesRead), c.MfaMiddleware, c.RequirePermission(model.PermissionRolesRead), c.PermissionController.List)
+
+	c.App.Get("/api/roles", c.AuthMiddleware(model.PermissionRolesRead), c.MfaMiddleware, c.RequirePermission(model.PermissionRolesRead), c.RoleController.List)
+	c.App.Post("/api/roles", c.AuthMiddleware(model.PermissionRolesWrite), c.MfaMiddleware, c.RequirePermission(model.PermissionRolesWrite), c.RoleController.Create)
+	c.App.Put("/api/roles/update/:roleId", c.AuthMiddleware(model.PermissionRolesWrite), c.MfaMiddleware, c.RequirePermission(model.PermissionRolesWrite), c.RoleController.Update)
+	c.App.Get("/api/roles/view/:roleId", c.AuthMiddleware(model.PermissionRolesRead), c.MfaMiddleware, c.RequirePermission(model.PermissionRolesRead), c.RoleController.Get)
+	c.App.Put("/api/roles/delete/:roleId", c.AuthMiddleware(model.PermissionRolesWrite), c.MfaMiddleware, c.RequirePermission(model.PermissionRolesWrite), c.RoleController.SoftDelete)
+	c.App.Get("/api/roles/trash", c.AuthMiddleware(model.PermissionRolesWrite), c.MfaMiddleware, c.RequirePermission(model.PermissionRolesWrite), c.RoleController.RecycleBin)
+	c.App.Put("/api/roles/restore/:roleId", c.AuthMiddleware(model.PermissionRolesWrite), c.MfaMiddleware, c.RequirePermission(model.PermissionRolesWrite), c.RoleController.Restore)
+	c.App.Delete("/api/roles/force/:roleId", c.AuthMiddleware(model.PermissionRolesWrite), c.MfaMiddleware, c.RequirePermission(model.PermissionRolesWrite), c.RoleController.ForceDelete)
+	c.App.Put("/api/roles/mfa/:roleId", c.AuthMiddleware(model.PermissionRolesWrite), c.MfaMiddleware, c.RequirePermission(model.PermissionRolesWrite), c.RoleController.UpdateMfa)
+	c.App.Get("/api/roles/permissions/:roleId", c.AuthMiddleware(model.PermissionRolesRead), c.MfaMiddleware, c.RequirePermission(model.PermissionRolesRead), c.PermissionController.Get)
+	c.App.Put("/api/roles/permissions/:roleId", c.AuthMiddleware(model.PermissionRolesWrite), c.MfaMiddleware, c.RequirePermission(model.PermissionRolesWrite), c.PermissionController.Update)
+
+	c.App.Get("/api/departments", c.AuthMiddleware(model.PermissionDepartmentsRead), c.MfaMiddleware, c.RequirePermission(model.PermissionDepartmentsRead), c.DepartmentController.List)
+	c.App.Post("/api/departments", c.AuthMiddleware(model.PermissionDepartmentsWrite), c.MfaMiddleware, c.RequirePermission(model.PermissionDepartmentsWrite), c.DepartmentController.Create)
+	c.App.Put("/api/departments/update/:departmentId", c.AuthMiddleware(model.PermissionDepartmentsWrite), c.MfaMiddleware, c.RequirePermission(model.PermissionDepartmentsWrite), c.DepartmentController.Update)
+	c.App.Get("/api/departments/view/:departmentId", c.AuthMiddleware(model.PermissionDepartmentsRead), c.MfaMiddleware, c.RequirePermission(model.PermissionDepartmentsRead), c.DepartmentController.Get)
+	c.App.Put("/api/departments/delete/:departmentId", c.AuthMiddleware(model.PermissionDepartmentsWrite), c.MfaMiddleware, c.RequirePermission(model.PermissionDepartmentsWrite), c.DepartmentController.SoftDelete)
+	c.App.Get("/api/departments/trash", c.AuthMiddleware(model.PermissionDepartmentsWrite), c.MfaMiddleware, c.RequirePermission(model.PermissionDepartmentsWrite), c.DepartmentController.RecycleBin)
+	c.App.Put("/api/departments/restore/:departmentId", c.AuthMiddleware(model.PermissionDepartmentsWrite), c.MfaMiddleware, c.RequirePermission(model.PermissionDepartmentsWrite), c.DepartmentController.Restore)
+	c.App.Delete("/api/departments/force/:departmentId", c.AuthMiddleware(model.PermissionDepartmentsWrite), c.MfaMiddleware, c.RequirePermission(model.PermissionDepartmentsWrite), c.DepartmentController.ForceDelete)
+	c.App.Put("/api/departments/move-users/:departmentId", c.AuthMiddleware(model.PermissionUsersAdmin), c.MfaMiddleware, c.RequirePermission(model.PermissionUsersAdmin), c.DepartmentController.MoveUsers)
+
+	c.App.Get("/api/projects", c.AuthMiddleware(model.ScopeProjectsRead), c.MfaMiddleware, c.ProjectController.List)
+	c.App.Post("/api/projects", c.AuthMiddleware(model.PermissionProjectsWrite), c.MfaMiddleware, c.RequirePermission(model.PermissionProjectsWrite), c.ProjectController.Create)
+	c.App.Put("/api/projects/update/:projectId", c.AuthMiddleware(model.PermissionProjectsWrite), c.MfaMiddleware, c.RequirePermission(model.PermissionProjectsWrite), c.ProjectController.Update)
+	c.App.Get("/api/projects/view/:projectId", c.AuthMiddleware(model.ScopeProjectsRead), c.MfaMiddleware, c.ProjectController.Get)
+	c.App.Put("/api/projects/delete/:projectId", c.AuthMiddleware(model.PermissionProjectsAdmin), c.MfaMiddleware, c.RequirePermission(model.PermissionProjectsAdmin), c.ProjectController.SoftDelete)
+	c.App.Get("/api/projects/trash", c.AuthMiddleware(model.PermissionProjectsAdmin), c.MfaMiddleware, c.RequirePermission(model.PermissionProjectsAdmin), c.ProjectController.RecycleBin)
+	c.App.Put("/api/projects/restore/:projectId", c.AuthMiddleware(model.PermissionProjectsAdmin), c.MfaMiddleware, c.RequirePermission(model.PermissionProjectsAdmin), c.ProjectController.Restore)
+	c.App.Delete("/api/projects/force/:projectId", c.AuthMiddleware(model.PermissionProjectsAdmin), c.MfaMiddleware, c.RequirePermission(model.PermissionProjectsAdmin), c.ProjectController.ForceDelete)
+
+	c.App.Get("/api/projects/:projectId/members", c.AuthMiddleware(model.ScopeProjectsRead), c.MfaMiddleware, c.MemberController.List)
+	c.App.Post("/api/projects/:projectId/members", c.AuthMiddleware(model.ScopeMembersWrite), c.MfaMiddleware, c.MemberController.Add)
+	c.App.Put("/api/projects/:projectId/members/update/:userId", c.AuthMiddleware(model.ScopeMembersWrite), c.MfaMiddleware, c.MemberController.Update)
+	c.App.Delete("/api/projects/:projectId/members/remove/:userId", c.AuthMiddleware(model.ScopeMembersWrite), c.MfaMiddleware, c.MemberController.Remove)
 
-	c.App.Get("/api/roles", c.RoleController.List)
-	c.App.Post("/api/roles", c.RoleController.Create)
-	c.App.Put("/api/roles/update/:roleId", c.RoleController.Update)
-	c.App.Get("/api/roles/view/:roleId", c.RoleController.Get)
-	c.App.Put("/api/roles/delete/:roleId", c.RoleController.SoftDelete)
-	c.App.Get("/api/roles/trash", c.RoleController.RecycleBin)
-	c.App.Put("/api/roles/restore/:roleId", c.RoleController.Restore)
-	c.App.Delete("/api/roles/force/:roleId", c.RoleController.ForceDelete)
+	c.App.Get("/api/projects/:projectId/boards", c.AuthMiddleware(model.ScopeProjectsRead), c.MfaMiddleware, c.BoardController.List)
+	c.App.Post("/api/projects/:projectId/boards", c.AuthMiddleware(model.ScopeBoardsWrite), c.MfaMiddleware, c.BoardController.Create)
+	c.App.Put("/api/projects/:projectId/boards/update/:boardId", c.AuthMiddleware(model.ScopeBoardsWrite), c.MfaMiddleware, c.BoardController.Update)
+	c.App.Get("/api/projects/:projectId/boards/view/:boardId", c.AuthMiddleware(model.ScopeProjectsRead), c.MfaMiddleware, c.BoardController.Get)
+	c.App.Put("/api/projects/:projectId/boards/delete/:boardId", c.AuthMiddleware(model.ScopeBoardsWrite), c.MfaMiddleware, c.BoardController.SoftDelete)
+	c.App.Get("/api/projects/:projectId/boards/trash", c.AuthMiddleware(model.ScopeBoardsWrite), c.MfaMiddleware, c.BoardController.RecycleBin)
+	c.App.Put("/api/projects/:projectId/boards/restore/:boardId", c.AuthMiddleware(model.ScopeBoardsWrite), c.MfaMiddleware, c.BoardController.Restore)
 
+	c.App.Get("/api/projects/:projectId/boards/:boardId/cards", c.AuthMiddleware(model.ScopeProjectsRead), c.MfaMiddleware, c.CardController.List)
+	c.App.Post("/api/projects/:projectId/boards/:boardId/cards", c.AuthMiddleware(model.ScopeCardsWrite), c.MfaMiddleware, c.CardController.Create)
+	c.App.Get("/api/projects/:projectId/cards/view/:cardId", c.AuthMiddleware(model.ScopeProjectsRead), c.MfaMiddleware, c.CardController.Get)
+	c.App.Put("/api/projects/:projectId/cards/move/:cardId", c.AuthMiddleware(model.ScopeCardsWrite), c.MfaMiddleware, c.CardController.Move)
+	c.App.Put("/api/projects/:projectId/cards/assign/:cardId", c.AuthMiddleware(model.ScopeCardsWrite), c.MfaMiddleware, c.CardController.Assign)
+	c.App.Put("/api/projects/:projectId/cards/close/:cardId", c.AuthMiddleware(model.ScopeCardsWrite), c.MfaMiddleware, c.CardController.Close)
+	c.App.Put("/api/projects/:projectId/cards/reopen/:cardId", c.AuthMiddleware(model.ScopeCardsWrite), c.MfaMiddleware, c.CardController.Reopen)
+	c.App.Put("/api/projects/:projectId/cards/delete/:cardId", c.AuthMiddleware(model.ScopeCardsWrite), c.MfaMiddleware, c.CardController.SoftDelete)
 }
@@ -0,0 +1,72 @@
+package http
+
+import (
+	"todo-app/internal/delivery/http/middleware"
+	"todo-app/internal/model"
+	"todo-app/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type SessionController struct {
+	UseCase *usecase.SessionUseCase
+	Log     *logrus.Logger
+}
+
+func NewSessionController(useCase *usecase.SessionUseCase, log *logrus.Logger) *SessionController {
+	return &SessionController{
+		UseCase: useCase,
+		Log:     log,
+	}
+}
+
+func (c *SessionController) List(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := &model.ListSessionRequest{
+		UserId:           auth.ID,
+		CurrentSessionId: auth.SessionId,
+	}
+
+	responses, err := c.UseCase.List(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to list sessions")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.SessionResponse]{Data: responses})
+}
+
+func (c *SessionController) Revoke(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := &model.RevokeSessionRequest{
+		UserId: auth.ID,
+		ID:     ctx.Params("sessionId"),
+	}
+
+	response, err := c.UseCase.Revoke(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to revoke session")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[bool]{Data: response})
+}
+
+func (c *SessionController) RevokeAll(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := &model.RevokeAllSessionRequest{
+		UserId: auth.ID,
+	}
+
+	response, err := c.UseCase.RevokeAll(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to revoke all sessions")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[bool]{Data: response})
+}
@@ -0,0 +1,179 @@
+package http
+
+import (
+	"math"
+	"strconv"
+	"todo-app/internal/delivery/http/middleware"
+	"todo-app/internal/model"
+	"todo-app/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type UserAdminController struct {
+	UseCase *usecase.UserAdminUseCase
+	Log     *logrus.Logger
+}
+
+func NewUserAdminController(useCase *usecase.UserAdminUseCase, log *logrus.Logger) *UserAdminController {
+	return &UserAdminController{
+		UseCase: useCase,
+		Log:     log,
+	}
+}
+
+func (c *UserAdminController) searchRequest(ctx *fiber.Ctx) (*model.SearchUserRequest, error) {
+	request := &model.SearchUserRequest{
+		Name:         ctx.Query("name", ""),
+		Email:        ctx.Query("email", ""),
+		RoleId:       ctx.Query("role_id", ""),
+		DepartmentId: ctx.Query("department_id", ""),
+		Page:         ctx.QueryInt("page", 1),
+		Size:         ctx.QueryInt("size", 10),
+	}
+
+	if value := ctx.Query("is_active", ""); value != "" {
+		isActive, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fiber.ErrBadRequest
+		}
+		request.IsActive = &isActive
+	}
+
+	return request, nil
+}
+
+func (c *UserAdminController) List(ctx *fiber.Ctx) error {
+	request, err := c.searchRequest(ctx)
+	if err != nil {
+		c.Log.WithError(err).Error("error parsing search query")
+		return err
+	}
+
+	responses, total, err := c.UseCase.Search(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error searching user")
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.AdminUserResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
+
+func (c *UserAdminController) Get(ctx *fiber.Ctx) error {
+	request := &model.AdminGetUserRequest{
+		ID: ctx.Params("userId"),
+	}
+
+	response, err := c.UseCase.Get(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting user")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.AdminUserResponse]{Data: response})
+}
+
+func (c *UserAdminController) Update(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := new(model.AdminUpdateUserRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.ErrBadRequest
+	}
+
+	request.ActorId = auth.ID
+	request.ID = ctx.Params("userId")
+
+	response, err := c.UseCase.Update(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error updating user")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.AdminUserResponse]{Data: response})
+}
+
+func (c *UserAdminController) SoftDelete(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := &model.AdminDeleteUserRequest{
+		ActorId: auth.ID,
+		ID:      ctx.Params("userId"),
+	}
+
+	response, err := c.UseCase.SoftDelete(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error deleting user")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.AdminUserResponse]{Data: response})
+}
+
+func (c *UserAdminController) RecycleBin(ctx *fiber.Ctx) error {
+	request, err := c.searchRequest(ctx)
+	if err != nil {
+		c.Log.WithError(err).Error("error parsing search query")
+		return err
+	}
+
+	responses, total, err := c.UseCase.RecycleBin(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting trashed users")
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.AdminUserResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
+
+func (c *UserAdminController) Restore(ctx *fiber.Ctx) error {
+	request := &model.AdminGetUserRequest{
+		ID: ctx.Params("userId"),
+	}
+
+	response, err := c.UseCase.Restore(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error restoring user")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.AdminUserResponse]{Data: response})
+}
+
+func (c *UserAdminController) ForceDelete(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := &model.AdminDeleteUserRequest{
+		ActorId: auth.ID,
+		ID:      ctx.Params("userId"),
+	}
+
+	if err := c.UseCase.ForceDelete(ctx.UserContext(), request); err != nil {
+		c.Log.WithError(err).Error("error force deleting user")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
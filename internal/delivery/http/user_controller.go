@@ -46,6 +46,9 @@ func (c *UserController) Login(ctx *fiber.Ctx) error {
 		return fiber.ErrBadRequest
 	}
 
+	request.IpAddress = ctx.IP()
+	request.UserAgent = userAgent(ctx)
+
 	response, err := c.UseCase.Login(ctx.UserContext(), request)
 	if err != nil {
 		c.Log.Warnf("Failed to login user : %+v", err)
@@ -55,20 +58,102 @@ func (c *UserController) Login(ctx *fiber.Ctx) error {
 	return ctx.JSON(model.WebResponse[*model.UserResponse]{Data: response})
 }
 
+func (c *UserController) VerifyEmail(ctx *fiber.Ctx) error {
+	request := new(model.VerifyEmailRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return fiber.ErrBadRequest
+	}
+
+	response, err := c.UseCase.VerifyEmail(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to verify email : %+v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.UserResponse]{Data: response})
+}
+
+func (c *UserController) ResendVerificationEmail(ctx *fiber.Ctx) error {
+	request := new(model.ResendVerificationEmailRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return fiber.ErrBadRequest
+	}
+
+	response, err := c.UseCase.ResendVerificationEmail(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to resend verification email : %+v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[bool]{Data: response})
+}
+
+func (c *UserController) ForgotPassword(ctx *fiber.Ctx) error {
+	request := new(model.ForgotPasswordRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return fiber.ErrBadRequest
+	}
+
+	response, err := c.UseCase.ForgotPassword(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to request password reset : %+v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[bool]{Data: response})
+}
+
+func (c *UserController) ResetPassword(ctx *fiber.Ctx) error {
+	request := new(model.ResetPasswordRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return fiber.ErrBadRequest
+	}
+
+	response, err := c.UseCase.ResetPassword(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to reset password : %+v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[bool]{Data: response})
+}
+
+func (c *UserController) LoginMfa(ctx *fiber.Ctx) error {
+	request := new(model.LoginMfaRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return fiber.ErrBadRequest
+	}
+
+	request.IpAddress = ctx.IP()
+	request.UserAgent = userAgent(ctx)
+
+	response, err := c.UseCase.LoginMfa(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to login user with mfa : %+v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.UserResponse]{Data: response})
+}
+
 func (c *UserController) Refresh(ctx *fiber.Ctx) error {
-	request := new(model.VerifyUserRequest)
+	request := new(model.RefreshUserRequest)
 	err := ctx.BodyParser(request)
 	if err != nil {
 		c.Log.Warnf("Failed to parse refresh request : %+v", err)
 		return fiber.ErrBadRequest
 	}
 
-	if request.Token == "" {
-		return fiber.NewError(fiber.StatusBadRequest, " token required")
-	}
+	request.IpAddress = ctx.IP()
+	request.UserAgent = userAgent(ctx)
 
 	// Panggil usecase Refresh
-	response, err := c.UseCase.Refresh(ctx.UserContext(), request.Token)
+	response, err := c.UseCase.Refresh(ctx.UserContext(), request)
 	if err != nil {
 		c.Log.Warnf("Failed to refresh token : %+v", err)
 		return err
@@ -98,7 +183,8 @@ func (c *UserController) Logout(ctx *fiber.Ctx) error {
 	auth := middleware.GetUser(ctx)
 
 	request := &model.LogoutUserRequest{
-		ID: auth.ID,
+		ID:        auth.ID,
+		SessionId: auth.SessionId,
 	}
 
 	response, err := c.UseCase.Logout(ctx.UserContext(), request)
@@ -110,6 +196,20 @@ func (c *UserController) Logout(ctx *fiber.Ctx) error {
 	return ctx.JSON(model.WebResponse[bool]{Data: response})
 }
 
+func (c *UserController) Unlock(ctx *fiber.Ctx) error {
+	request := &model.UnlockUserRequest{
+		ID: ctx.Params("userId"),
+	}
+
+	response, err := c.UseCase.Unlock(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to unlock user")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.UserResponse]{Data: response})
+}
+
 func (c *UserController) Update(ctx *fiber.Ctx) error {
 	auth := middleware.GetUser(ctx)
 
@@ -120,6 +220,7 @@ func (c *UserController) Update(ctx *fiber.Ctx) error {
 	}
 
 	request.ID = auth.ID
+	request.IpAddress = ctx.IP()
 	response, err := c.UseCase.Update(ctx.UserContext(), request)
 	if err != nil {
 		c.Log.WithError(err).Warnf("Failed to update user")
@@ -128,3 +229,12 @@ func (c *UserController) Update(ctx *fiber.Ctx) error {
 
 	return ctx.JSON(model.WebResponse[*model.UserResponse]{Data: response})
 }
+
+// userAgent memotong header User-Agent agar muat di kolom user_sessions.user_agent
+func userAgent(ctx *fiber.Ctx) string {
+	ua := ctx.Get(fiber.HeaderUserAgent)
+	if len(ua) > 500 {
+		return ua[:500]
+	}
+	return ua
+}
@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+const (
+	AuditActionCreate      = "create"
+	AuditActionUpdate      = "update"
+	AuditActionDelete      = "delete"
+	AuditActionRestore     = "restore"
+	AuditActionForceDelete = "force_delete"
+	AuditActionLogin       = "login"
+)
+
+// AuditLog is a struct that represents one append-only audit entry.
+// BeforeData and AfterData hold only the changed columns (JSON), sensitive columns are redacted.
+type AuditLog struct {
+	ID         string    `gorm:"column:id;primaryKey"`
+	ActorId    *string   `gorm:"column:actor_id"`
+	Action     string    `gorm:"column:action"`
+	EntityType string    `gorm:"column:entity_type"`
+	EntityId   string    `gorm:"column:entity_id"`
+	BeforeData *string   `gorm:"column:before_data"`
+	AfterData  *string   `gorm:"column:after_data"`
+	IpAddress  string    `gorm:"column:ip_address"`
+	RequestId  string    `gorm:"column:request_id"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime:milli"`
+}
+
+func (a *AuditLog) TableName() string {
+	return "audit_logs"
+}
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Board is a struct that represents a board (column) inside a project
+type Board struct {
+	ID        string         `gorm:"column:id;primaryKey"`
+	ProjectId string         `gorm:"column:project_id"`
+	Name      string         `gorm:"column:name"`
+	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+func (b *Board) TableName() string {
+	return "boards"
+}
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Card is a struct that represents a card placed on a board
+type Card struct {
+	ID        string         `gorm:"column:id;primaryKey"`
+	BoardId   string         `gorm:"column:board_id"`
+	UserId    *string        `gorm:"column:user_id"`
+	Name      string         `gorm:"column:name"`
+	IsClosed  bool           `gorm:"column:is_closed"`
+	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+func (c *Card) TableName() string {
+	return "cards"
+}
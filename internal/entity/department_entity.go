@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Department is a struct that represents a department entity
+type Department struct {
+	ID        string         `gorm:"column:id;primaryKey"`
+	Name      string         `gorm:"column:name"`
+	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+func (d *Department) TableName() string {
+	return "departments"
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// LoginThrottle is a struct that represents the failed login counter of one account or one IP address
+type LoginThrottle struct {
+	Key           string     `gorm:"column:throttle_key;primaryKey"`
+	FailedCount   int        `gorm:"column:failed_count"`
+	LastFailedAt  time.Time  `gorm:"column:last_failed_at"`
+	NextAttemptAt *time.Time `gorm:"column:next_attempt_at"`
+	LockedUntil   *time.Time `gorm:"column:locked_until"`
+	CreatedAt     time.Time  `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+}
+
+func (l *LoginThrottle) TableName() string {
+	return "login_throttles"
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// OidcLoginState is a struct that represents a started OIDC login, kept until the identity provider redirects back.
+// Only the hash of the state is stored; the PKCE code verifier and nonce never leave the server.
+type OidcLoginState struct {
+	ID           string     `gorm:"column:id;primaryKey"`
+	StateHash    string     `gorm:"column:state_hash"`
+	CodeVerifier string     `gorm:"column:code_verifier"`
+	Nonce        string     `gorm:"column:nonce"`
+	ExpiresAt    time.Time  `gorm:"column:expires_at"`
+	UsedAt       *time.Time `gorm:"column:used_at"`
+	CreatedAt    time.Time  `gorm:"column:created_at;autoCreateTime:milli"`
+}
+
+func (s *OidcLoginState) TableName() string {
+	return "oidc_login_states"
+}
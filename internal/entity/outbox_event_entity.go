@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// OutboxEvent is a struct that represents an event written in the same transaction as the change it describes.
+// The outbox relay in the worker publishes it to Topic with its envelope and sets PublishedAt.
+type OutboxEvent struct {
+	ID            int64      `gorm:"column:id;primaryKey;autoIncrement"`
+	EventId       string     `gorm:"column:event_id"`
+	EventType     string     `gorm:"column:event_type"`
+	EventVersion  int        `gorm:"column:event_version"`
+	CorrelationId *string    `gorm:"column:correlation_id"`
+	Topic         string     `gorm:"column:topic"`
+	EventKey      string     `gorm:"column:event_key"`
+	Payload       string     `gorm:"column:payload"`
+	Attempts      int        `gorm:"column:attempts"`
+	LastError     *string    `gorm:"column:last_error"`
+	CreatedAt     time.Time  `gorm:"column:created_at;autoCreateTime:milli"`
+	PublishedAt   *time.Time `gorm:"column:published_at"`
+}
+
+func (o *OutboxEvent) TableName() string {
+	return "outbox_events"
+}
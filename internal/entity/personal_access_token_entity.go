@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// PersonalAccessToken is a struct that represents a hashed long-lived token for scripts and CI.
+// Scopes holds the granted permissions separated by comma.
+type PersonalAccessToken struct {
+	ID          string     `gorm:"column:id;primaryKey"`
+	UserId      string     `gorm:"column:user_id"`
+	Name        string     `gorm:"column:name"`
+	TokenPrefix string     `gorm:"column:token_prefix"`
+	TokenHash   string     `gorm:"column:token_hash"`
+	Scopes      string     `gorm:"column:scopes"`
+	ExpiresAt   *time.Time `gorm:"column:expires_at"`
+	LastUsedAt  *time.Time `gorm:"column:last_used_at"`
+	RevokedAt   *time.Time `gorm:"column:revoked_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+}
+
+func (p *PersonalAccessToken) TableName() string {
+	return "personal_access_tokens"
+}
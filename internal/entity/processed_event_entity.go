@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// ProcessedEvent is a struct that represents an event a consumer already handled, it is stored in the same
+// transaction as the side effects of the consumer so a redelivered event is skipped.
+type ProcessedEvent struct {
+	Consumer    string    `gorm:"column:consumer;primaryKey"`
+	EventId     string    `gorm:"column:event_id;primaryKey"`
+	ProcessedAt time.Time `gorm:"column:processed_at;autoCreateTime:milli"`
+}
+
+func (p *ProcessedEvent) TableName() string {
+	return "processed_events"
+}
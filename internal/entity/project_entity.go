@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Project is a struct that represents a project entity
+type Project struct {
+	ID           string         `gorm:"column:id;primaryKey"`
+	Name         string         `gorm:"column:name"`
+	DepartmentId string         `gorm:"column:department_id"`
+	CreatedAt    time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt    time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+	DeletedAt    gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+func (p *Project) TableName() string {
+	return "projects"
+}
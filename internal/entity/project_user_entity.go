@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ProjectUser is a struct that represents a user membership in a project
+type ProjectUser struct {
+	ID        string         `gorm:"column:id;primaryKey"`
+	ProjectId string         `gorm:"column:project_id"`
+	UserId    string         `gorm:"column:user_id"`
+	Role      string         `gorm:"column:role"`
+	User      *User          `gorm:"foreignKey:UserId;references:ID"`
+	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+func (p *ProjectUser) TableName() string {
+	return "project_users"
+}
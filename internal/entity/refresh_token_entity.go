@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// RefreshToken is a struct that represents a hashed opaque refresh token.
+// Every rotation creates a new token in the same family.
+type RefreshToken struct {
+	ID         string     `gorm:"column:id;primaryKey"`
+	UserId     string     `gorm:"column:user_id"`
+	FamilyId   string     `gorm:"column:family_id"`
+	TokenHash  string     `gorm:"column:token_hash"`
+	ExpiresAt  time.Time  `gorm:"column:expires_at"`
+	RotatedAt  *time.Time `gorm:"column:rotated_at"`
+	ReplacedBy *string    `gorm:"column:replaced_by"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at"`
+	CreatedAt  time.Time  `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt  time.Time  `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+}
+
+func (r *RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
@@ -7,11 +7,12 @@ import (
 )
 
 type Role struct {
-	ID        string         `gorm:"column:id;primaryKey"`
-	Name      string         `gorm:"column:name"`
-	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
-	UpdatedAt time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
-	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+	ID          string         `gorm:"column:id;primaryKey"`
+	Name        string         `gorm:"column:name"`
+	MfaRequired bool           `gorm:"column:mfa_required"`
+	CreatedAt   time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt   time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+	DeletedAt   gorm.DeletedAt `gorm:"column:deleted_at;index"`
 }
 
 func (c *Role) TableName() string {
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// RolePermission is a struct that represents a named permission granted to a role
+type RolePermission struct {
+	ID         string    `gorm:"column:id;primaryKey"`
+	RoleId     string    `gorm:"column:role_id"`
+	Permission string    `gorm:"column:permission"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt  time.Time `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+}
+
+func (r *RolePermission) TableName() string {
+	return "role_permissions"
+}
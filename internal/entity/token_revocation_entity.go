@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// TokenRevocation is a struct that represents a revoked access token, every token of a session
+// when SessionId is set, or every token of a user issued before RevokedAt when both are nil
+type TokenRevocation struct {
+	ID        string    `gorm:"column:id;primaryKey"`
+	UserId    string    `gorm:"column:user_id"`
+	TokenId   *string   `gorm:"column:token_id"`
+	SessionId *string   `gorm:"column:session_id"`
+	RevokedAt time.Time `gorm:"column:revoked_at"`
+	ExpiresAt time.Time `gorm:"column:expires_at"`
+}
+
+func (t *TokenRevocation) TableName() string {
+	return "token_revocations"
+}
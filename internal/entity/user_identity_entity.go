@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// UserIdentity is a struct that links a local user to an account (issuer + subject) at an OIDC identity provider
+type UserIdentity struct {
+	ID          string     `gorm:"column:id;primaryKey"`
+	UserId      string     `gorm:"column:user_id"`
+	Issuer      string     `gorm:"column:issuer"`
+	Subject     string     `gorm:"column:subject"`
+	Email       string     `gorm:"column:email"`
+	LastLoginAt *time.Time `gorm:"column:last_login_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+}
+
+func (i *UserIdentity) TableName() string {
+	return "user_identities"
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// UserMfa is a struct that represents the TOTP enrollment of a user.
+// The enrollment only counts once ConfirmedAt is set.
+type UserMfa struct {
+	UserId       string     `gorm:"column:user_id;primaryKey"`
+	TotpSecret   string     `gorm:"column:totp_secret"`
+	ConfirmedAt  *time.Time `gorm:"column:confirmed_at"`
+	LastUsedStep int64      `gorm:"column:last_used_step"`
+	CreatedAt    time.Time  `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt    time.Time  `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+}
+
+func (m *UserMfa) TableName() string {
+	return "user_mfa"
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// UserRecoveryCode is a struct that represents a hashed one-time MFA recovery code
+type UserRecoveryCode struct {
+	ID        string     `gorm:"column:id;primaryKey"`
+	UserId    string     `gorm:"column:user_id"`
+	CodeHash  string     `gorm:"column:code_hash"`
+	UsedAt    *time.Time `gorm:"column:used_at"`
+	CreatedAt time.Time  `gorm:"column:created_at;autoCreateTime:milli"`
+}
+
+func (r *UserRecoveryCode) TableName() string {
+	return "user_recovery_codes"
+}
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// UserSession is a struct that represents one logged in device of a user.
+// The session id is also the family id of its refresh tokens and the sid claim of its access tokens.
+type UserSession struct {
+	ID          string     `gorm:"column:id;primaryKey"`
+	UserId      string     `gorm:"column:user_id"`
+	DeviceLabel string     `gorm:"column:device_label"`
+	IpAddress   string     `gorm:"column:ip_address"`
+	UserAgent   string     `gorm:"column:user_agent"`
+	LastSeenAt  time.Time  `gorm:"column:last_seen_at"`
+	ExpiresAt   time.Time  `gorm:"column:expires_at"`
+	RevokedAt   *time.Time `gorm:"column:revoked_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+}
+
+func (s *UserSession) TableName() string {
+	return "user_sessions"
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+const (
+	UserTokenPurposeEmailVerification = "email_verification"
+	UserTokenPurposePasswordReset     = "password_reset"
+	UserTokenPurposeMfaChallenge      = "mfa_challenge"
+)
+
+// UserToken is a struct that represents a hashed single-use token sent to the user by email
+type UserToken struct {
+	ID        string     `gorm:"column:id;primaryKey"`
+	UserId    string     `gorm:"column:user_id"`
+	Purpose   string     `gorm:"column:purpose"`
+	TokenHash string     `gorm:"column:token_hash"`
+	ExpiresAt time.Time  `gorm:"column:expires_at"`
+	UsedAt    *time.Time `gorm:"column:used_at"`
+	CreatedAt time.Time  `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time  `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+}
+
+func (t *UserToken) TableName() string {
+	return "user_tokens"
+}
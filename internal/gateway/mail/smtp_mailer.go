@@ -0,0 +1,116 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type SMTPMailer struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	Timeout  time.Duration
+	Log      *logrus.Logger
+}
+
+func NewSMTPMailer(host string, port int, username string, password string, from string, log *logrus.Logger) *SMTPMailer {
+	return &SMTPMailer{
+		Host:     host,
+		Port:     port,
+		Username: username,
+		Password: password,
+		From:     from,
+		Timeout:  10 * time.Second,
+		Log:      log,
+	}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, message *Message) error {
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
+	defer cancel()
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(m.Host, strconv.Itoa(m.Port)))
+	if err != nil {
+		m.Log.WithError(err).Error("failed to connect smtp server")
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, m.Host)
+	if err != nil {
+		conn.Close()
+		m.Log.WithError(err).Error("failed to create smtp client")
+		return err
+	}
+	defer client.Close()
+
+	// Server lokal untuk testing (mailpit, mailhog) biasanya tanpa TLS dan tanpa auth
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.Host}); err != nil {
+			m.Log.WithError(err).Error("failed to start tls")
+			return err
+		}
+	}
+	if m.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.Username, m.Password, m.Host)); err != nil {
+			m.Log.WithError(err).Error("failed to authenticate smtp")
+			return err
+		}
+	}
+
+	if err := client.Mail(m.From); err != nil {
+		m.Log.WithError(err).Error("failed to set mail sender")
+		return err
+	}
+	if err := client.Rcpt(message.To); err != nil {
+		m.Log.WithError(err).Error("failed to set mail recipient")
+		return err
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		m.Log.WithError(err).Error("failed to open mail data")
+		return err
+	}
+	if _, err := writer.Write(m.build(message)); err != nil {
+		m.Log.WithError(err).Error("failed to write mail data")
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		m.Log.WithError(err).Error("failed to send mail data")
+		return err
+	}
+
+	return client.Quit()
+}
+
+func (m *SMTPMailer) build(message *Message) []byte {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "From: %s\r\n", headerValue(m.From))
+	fmt.Fprintf(&builder, "To: %s\r\n", headerValue(message.To))
+	fmt.Fprintf(&builder, "Subject: %s\r\n", headerValue(message.Subject))
+	fmt.Fprintf(&builder, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	builder.WriteString("MIME-Version: 1.0\r\n")
+	builder.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	builder.WriteString("\r\n")
+	builder.WriteString(strings.ReplaceAll(message.Body, "\n", "\r\n"))
+	return []byte(builder.String())
+}
+
+// headerValue membuang CR/LF supaya nilai header tidak bisa menyisipkan header lain
+func headerValue(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
@@ -0,0 +1,184 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+// smtpServer adalah server SMTP palsu tanpa TLS dan auth, mencatat envelope dan data mail yang diterima
+type smtpServer struct {
+	listener net.Listener
+	// rejectRcpt membuat server menolak semua penerima
+	rejectRcpt bool
+
+	mu   sync.Mutex
+	from string
+	to   []string
+	data string
+}
+
+func newSMTPServer(t *testing.T) *smtpServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &smtpServer{listener: listener}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+func (s *smtpServer) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) {
+		io.WriteString(conn, line+"\r\n")
+	}
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.TrimRight(line, "\r\n")
+		verb := strings.ToUpper(strings.SplitN(command, " ", 2)[0])
+
+		switch verb {
+		case "EHLO", "HELO":
+			reply("250 localhost")
+		case "MAIL":
+			s.mu.Lock()
+			s.from = command
+			s.mu.Unlock()
+			reply("250 OK")
+		case "RCPT":
+			if s.rejectRcpt {
+				reply("550 mailbox unavailable")
+				continue
+			}
+			s.mu.Lock()
+			s.to = append(s.to, command)
+			s.mu.Unlock()
+			reply("250 OK")
+		case "DATA":
+			reply("354 end data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(line)
+			}
+			s.mu.Lock()
+			s.data = data.String()
+			s.mu.Unlock()
+			reply("250 OK")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 command not implemented")
+		}
+	}
+}
+
+func (s *smtpServer) mailer() *SMTPMailer {
+	host, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	return NewSMTPMailer(host, portNumber, "", "", "no-reply@todo.app", log)
+}
+
+func TestSMTPMailerSendsMessage(t *testing.T) {
+	server := newSMTPServer(t)
+
+	err := server.mailer().Send(context.Background(), &Message{
+		To:      "jane@example.com",
+		Subject: "Reset password\r\nBcc: evil@example.com",
+		Body:    "Halo Jane,\n\ntoken-1\n",
+	})
+	if err != nil {
+		t.Fatalf("Send returned %v", err)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if server.from != "MAIL FROM:<no-reply@todo.app>" || len(server.to) != 1 || server.to[0] != "RCPT TO:<jane@example.com>" {
+		t.Fatalf("unexpected envelope from %q to %q", server.from, server.to)
+	}
+
+	headers, body, _ := strings.Cut(server.data, "\r\n\r\n")
+	for _, header := range []string{"From: no-reply@todo.app", "To: jane@example.com", "Subject: Reset passwordBcc: evil@example.com",
+		"Content-Type: text/plain; charset=UTF-8"} {
+		if !strings.Contains(headers+"\r\n", header+"\r\n") {
+			t.Errorf("headers do not contain %q:\n%s", header, headers)
+		}
+	}
+	if strings.Contains(headers, "\r\nBcc:") {
+		t.Errorf("subject injected a header:\n%s", headers)
+	}
+	if body != "Halo Jane,\r\n\r\ntoken-1\r\n" {
+		t.Errorf("body is %q", body)
+	}
+}
+
+func TestSMTPMailerReturnsRejectedRecipient(t *testing.T) {
+	server := newSMTPServer(t)
+	server.rejectRcpt = true
+
+	err := server.mailer().Send(context.Background(), &Message{To: "jane@example.com", Subject: "Verifikasi email", Body: "token-1"})
+	if err == nil || !strings.Contains(err.Error(), "550") {
+		t.Fatalf("Send returned %v, want the 550 reply", err)
+	}
+}
+
+func TestSMTPMailerReturnsConnectionError(t *testing.T) {
+	server := newSMTPServer(t)
+	mailer := server.mailer()
+	server.listener.Close()
+
+	if err := mailer.Send(context.Background(), &Message{To: "jane@example.com", Subject: "Verifikasi email", Body: "token-1"}); err == nil {
+		t.Fatal("Send returned no error without a server")
+	}
+}
+
+func TestLogMailerRedactsBody(t *testing.T) {
+	log, hook := test.NewNullLogger()
+
+	if err := NewLogMailer(log).Send(context.Background(), &Message{To: "jane@example.com", Subject: "Reset password", Body: "token-1"}); err != nil {
+		t.Fatalf("Send returned %v", err)
+	}
+
+	entry := hook.LastEntry()
+	if entry == nil || entry.Data["to"] != "jane@example.com" || entry.Data["subject"] != "Reset password" {
+		t.Fatalf("unexpected log entry %+v", entry)
+	}
+	if line, _ := entry.String(); strings.Contains(line, "token-1") {
+		t.Fatalf("log entry contains the body: %s", line)
+	}
+}
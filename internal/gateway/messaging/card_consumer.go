@@ -0,0 +1,53 @@
+package messaging
+
+import (
+	"context"
+	"todo-app/internal/gateway/search"
+	"todo-app/internal/model"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// CardMovedConsumer updates the board of the card in the search index
+type CardMovedConsumer struct {
+	Log     *logrus.Logger
+	Indexer search.Indexer
+}
+
+func NewCardMovedConsumer(log *logrus.Logger, indexer search.Indexer) *CardMovedConsumer {
+	return &CardMovedConsumer{
+		Log:     log,
+		Indexer: indexer,
+	}
+}
+
+func (c *CardMovedConsumer) Handle(ctx context.Context, tx *gorm.DB, event *model.CardMovedEvent) error {
+	return c.Indexer.UpdateCard(ctx, event.ID, map[string]any{
+		"project_id": event.ProjectId,
+		"board_id":   event.BoardId,
+		"updated_at": event.MovedAt,
+	})
+}
+
+// CardClosedConsumer marks the card as closed in the search index
+type CardClosedConsumer struct {
+	Log     *logrus.Logger
+	Indexer search.Indexer
+}
+
+func NewCardClosedConsumer(log *logrus.Logger, indexer search.Indexer) *CardClosedConsumer {
+	return &CardClosedConsumer{
+		Log:     log,
+		Indexer: indexer,
+	}
+}
+
+func (c *CardClosedConsumer) Handle(ctx context.Context, tx *gorm.DB, event *model.CardClosedEvent) error {
+	return c.Indexer.UpdateCard(ctx, event.ID, map[string]any{
+		"project_id": event.ProjectId,
+		"board_id":   event.BoardId,
+		"is_closed":  true,
+		"updated_at": event.ClosedAt,
+	})
+}
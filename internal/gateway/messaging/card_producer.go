@@ -0,0 +1,36 @@
+package messaging
+
+import (
+	"todo-app/internal/model"
+
+	"github.com/IBM/sarama"
+	"github.com/sirupsen/logrus"
+)
+
+type CardMovedProducer struct {
+	Producer[*model.CardMovedEvent]
+}
+
+func NewCardMovedProducer(producer sarama.SyncProducer, log *logrus.Logger) *CardMovedProducer {
+	return &CardMovedProducer{
+		Producer: Producer[*model.CardMovedEvent]{
+			Producer: producer,
+			Topic:    TopicCardMoved,
+			Log:      log,
+		},
+	}
+}
+
+type CardClosedProducer struct {
+	Producer[*model.CardClosedEvent]
+}
+
+func NewCardClosedProducer(producer sarama.SyncProducer, log *logrus.Logger) *CardClosedProducer {
+	return &CardClosedProducer{
+		Producer: Producer[*model.CardClosedEvent]{
+			Producer: producer,
+			Topic:    TopicCardClosed,
+			Log:      log,
+		},
+	}
+}
@@ -0,0 +1,166 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/sirupsen/logrus"
+)
+
+type ConsumerHandler func(ctx context.Context, message *sarama.ConsumerMessage) error
+
+// ConsumerGroupHandler sends every message of a claim to the handler of its topic, so one consumer group
+// can consume every topic. A failed message is retried according to Policy and then moved to the next
+// retry topic or the dead-letter topic, it is only marked after the handler succeeds or the message has been forwarded.
+type ConsumerGroupHandler struct {
+	Handlers map[string]ConsumerHandler
+	Producer sarama.SyncProducer
+	Policy   RetryPolicy
+	Log      *logrus.Logger
+}
+
+func NewConsumerGroupHandler(handlers map[string]ConsumerHandler, producer sarama.SyncProducer, policy RetryPolicy, log *logrus.Logger) *ConsumerGroupHandler {
+	return &ConsumerGroupHandler{
+		Handlers: handlers,
+		Producer: producer,
+		Policy:   policy,
+		Log:      log,
+	}
+}
+
+// Topics returns the topics that have a handler, sorted so the subscription is stable
+func (h *ConsumerGroupHandler) Topics() []string {
+	topics := make([]string, 0, len(h.Handlers))
+	for topic := range h.Handlers {
+		topics = append(topics, topic)
+	}
+	slices.Sort(topics)
+	return topics
+}
+
+func (h *ConsumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *ConsumerGroupHandler) Cleanup(session sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *ConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	ctx := session.Context()
+	for {
+		select {
+		case message, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+			// Rebalance atau shutdown, message yang belum di-mark akan dibaca ulang oleh consumer berikutnya
+			if !waitRetryAt(ctx, message) {
+				return nil
+			}
+
+			err := h.handle(ctx, message)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				if !h.forward(ctx, message, err) {
+					return nil
+				}
+			}
+			session.MarkMessage(message, "")
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// handle menjalankan handler sampai Policy.Attempts kali dengan backoff yang berlipat dua
+func (h *ConsumerGroupHandler) handle(ctx context.Context, message *sarama.ConsumerMessage) error {
+	handler, ok := h.Handlers[message.Topic]
+	if !ok {
+		return Permanent(fmt.Errorf("no handler for topic %s", message.Topic))
+	}
+
+	backoff := h.Policy.Backoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = handler(ctx, message); err == nil {
+			return nil
+		}
+		if IsPermanent(err) || attempt >= h.Policy.Attempts {
+			return err
+		}
+
+		h.Log.WithError(err).Warnf("Failed to process message from topic %s, partition %d, offset %d, attempt %d of %d",
+			message.Topic, message.Partition, message.Offset, attempt, h.Policy.Attempts)
+		if !sleep(ctx, backoff) {
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+// forward memindahkan message yang gagal ke retry topic berikutnya atau ke dead-letter topic.
+// Selama producer gagal, pengiriman diulang supaya partition tidak melewati message tersebut;
+// false jika ctx selesai lebih dulu, message tidak di-mark dan dibaca ulang setelah consumer bergabung lagi.
+func (h *ConsumerGroupHandler) forward(ctx context.Context, message *sarama.ConsumerMessage, cause error) bool {
+	failed := failedMessage(message, h.Policy, cause, time.Now())
+	h.Log.WithError(cause).Errorf("Failed to process message from topic %s, partition %d, offset %d, moving it to %s",
+		message.Topic, message.Partition, message.Offset, failed.Topic)
+
+	backoff := h.Policy.Backoff
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+	for {
+		_, _, err := h.Producer.SendMessage(failed)
+		if err == nil {
+			return true
+		}
+		h.Log.WithError(err).Errorf("Failed to send message to topic %s", failed.Topic)
+		if !sleep(ctx, backoff) {
+			return false
+		}
+		if backoff < time.Minute {
+			backoff *= 2
+		}
+	}
+}
+
+// ConsumeTopics keeps consuming the topics until ctx is cancelled or the consumer group is closed.
+// Consume returns on every rebalance, so it is called again in a loop. Other errors, e.g. brokers that are
+// unreachable for a while, are logged and Consume is called again after an exponential backoff.
+func ConsumeTopics(ctx context.Context, consumerGroup sarama.ConsumerGroup, topics []string, backoff time.Duration,
+	log *logrus.Logger, consumerHandler sarama.ConsumerGroupHandler) error {
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+	delay := backoff
+	for {
+		err := consumerGroup.Consume(ctx, topics, consumerHandler)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if errors.Is(err, sarama.ErrClosedConsumerGroup) {
+			log.WithError(err).Errorf("Consumer group for topics %v is closed", topics)
+			return err
+		}
+		if err == nil {
+			delay = backoff
+			continue
+		}
+
+		log.WithError(err).Errorf("Error consuming topics %v, retrying in %s", topics, delay)
+		if !sleep(ctx, delay) {
+			return nil
+		}
+		if delay < time.Minute {
+			delay *= 2
+		}
+	}
+}
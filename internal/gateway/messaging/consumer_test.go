@@ -0,0 +1,322 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/sirupsen/logrus"
+)
+
+type fakeSession struct {
+	ctx    context.Context
+	mu     sync.Mutex
+	marked []*sarama.ConsumerMessage
+}
+
+func (s *fakeSession) Claims() map[string][]int32                                               { return nil }
+func (s *fakeSession) MemberID() string                                                         { return "member" }
+func (s *fakeSession) GenerationID() int32                                                      { return 1 }
+func (s *fakeSession) MarkOffset(topic string, partition int32, offset int64, metadata string)  {}
+func (s *fakeSession) Commit()                                                                  {}
+func (s *fakeSession) ResetOffset(topic string, partition int32, offset int64, metadata string) {}
+func (s *fakeSession) Context() context.Context                                                 { return s.ctx }
+
+func (s *fakeSession) MarkMessage(message *sarama.ConsumerMessage, metadata string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.marked = append(s.marked, message)
+}
+
+func (s *fakeSession) markedOffsets() []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	offsets := make([]int64, 0, len(s.marked))
+	for _, message := range s.marked {
+		offsets = append(offsets, message.Offset)
+	}
+	return offsets
+}
+
+type fakeClaim struct {
+	topic    string
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c *fakeClaim) Topic() string                            { return c.topic }
+func (c *fakeClaim) Partition() int32                         { return 0 }
+func (c *fakeClaim) InitialOffset() int64                     { return 0 }
+func (c *fakeClaim) HighWaterMarkOffset() int64               { return int64(len(c.messages)) }
+func (c *fakeClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
+
+func newFakeClaim(topic string, messages ...*sarama.ConsumerMessage) *fakeClaim {
+	claim := &fakeClaim{topic: topic, messages: make(chan *sarama.ConsumerMessage, len(messages))}
+	for _, message := range messages {
+		claim.messages <- message
+	}
+	return claim
+}
+
+func newTestLogger() *logrus.Logger {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	return log
+}
+
+func testMessage(topic string, offset int64) *sarama.ConsumerMessage {
+	return &sarama.ConsumerMessage{Topic: topic, Offset: offset, Key: []byte("key"), Value: []byte("{}")}
+}
+
+// consumeClaim menjalankan ConsumeClaim di goroutine dan mengembalikan channel hasilnya
+func consumeClaim(handler *ConsumerGroupHandler, session *fakeSession, claim *fakeClaim) <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		done <- handler.ConsumeClaim(session, claim)
+	}()
+	return done
+}
+
+func waitDone(t *testing.T, done <-chan error) {
+	t.Helper()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("ConsumeClaim returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ConsumeClaim did not return after the session was cancelled")
+	}
+}
+
+func waitMarked(t *testing.T, session *fakeSession, count int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for len(session.markedOffsets()) < count {
+		if time.Now().After(deadline) {
+			t.Fatalf("marked %v, want %d messages", session.markedOffsets(), count)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestConsumerGroupHandlerMarksHandledMessages(t *testing.T) {
+	var mu sync.Mutex
+	var handled []string
+	record := func(name string) ConsumerHandler {
+		return func(ctx context.Context, message *sarama.ConsumerMessage) error {
+			mu.Lock()
+			defer mu.Unlock()
+			handled = append(handled, name)
+			return nil
+		}
+	}
+	handler := NewConsumerGroupHandler(map[string]ConsumerHandler{
+		TopicCardMoved:                record("card.moved"),
+		RetryTopic(TopicCardMoved, 1): record("card.moved.retry.1"),
+	}, nil, RetryPolicy{Attempts: 1}, newTestLogger())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	session := &fakeSession{ctx: ctx}
+	done := consumeClaim(handler, session, newFakeClaim(TopicCardMoved, testMessage(TopicCardMoved, 0), testMessage(TopicCardMoved, 1)))
+	waitMarked(t, session, 2)
+	cancel()
+	waitDone(t, done)
+
+	if got := session.markedOffsets(); len(got) != 2 || got[0] != 0 || got[1] != 1 {
+		t.Fatalf("marked offsets %v, want [0 1]", got)
+	}
+	if len(handled) != 2 || handled[0] != "card.moved" {
+		t.Fatalf("handled by %v, want the card.moved handler", handled)
+	}
+}
+
+func TestConsumerGroupHandlerForwardsFailedMessage(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, nil)
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(message *sarama.ProducerMessage) error {
+		if message.Topic != RetryTopic(TopicCardMoved, 1) {
+			return errors.New("message sent to " + message.Topic)
+		}
+		return nil
+	})
+	defer producer.Close()
+
+	attempts := 0
+	handler := NewConsumerGroupHandler(map[string]ConsumerHandler{
+		TopicCardMoved: func(ctx context.Context, message *sarama.ConsumerMessage) error {
+			attempts++
+			return errors.New("index unavailable")
+		},
+	}, producer, RetryPolicy{Attempts: 2, Backoff: time.Millisecond, RetryTopics: 1, RetryDelay: time.Minute}, newTestLogger())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	session := &fakeSession{ctx: ctx}
+	done := consumeClaim(handler, session, newFakeClaim(TopicCardMoved, testMessage(TopicCardMoved, 0)))
+	waitMarked(t, session, 1)
+	cancel()
+	waitDone(t, done)
+
+	if attempts != 2 {
+		t.Fatalf("handler ran %d times, want 2", attempts)
+	}
+}
+
+func TestConsumerGroupHandlerSendsUnknownTopicToDeadLetter(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, nil)
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(message *sarama.ProducerMessage) error {
+		if message.Topic != DeadLetterTopic("unknown") {
+			return errors.New("message sent to " + message.Topic)
+		}
+		return nil
+	})
+	defer producer.Close()
+
+	handler := NewConsumerGroupHandler(map[string]ConsumerHandler{}, producer, RetryPolicy{Attempts: 3, RetryTopics: 2}, newTestLogger())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	session := &fakeSession{ctx: ctx}
+	done := consumeClaim(handler, session, newFakeClaim("unknown", testMessage("unknown", 0)))
+	waitMarked(t, session, 1)
+	cancel()
+	waitDone(t, done)
+}
+
+func TestConsumerGroupHandlerStopsOnCancelWithoutMarking(t *testing.T) {
+	started := make(chan struct{})
+	handler := NewConsumerGroupHandler(map[string]ConsumerHandler{
+		TopicCardMoved: func(ctx context.Context, message *sarama.ConsumerMessage) error {
+			close(started)
+			return errors.New("index unavailable")
+		},
+	}, nil, RetryPolicy{Attempts: 3, Backoff: time.Hour}, newTestLogger())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	session := &fakeSession{ctx: ctx}
+	done := consumeClaim(handler, session, newFakeClaim(TopicCardMoved, testMessage(TopicCardMoved, 0)))
+
+	// Shutdown saat handler menunggu backoff, message dibaca ulang oleh consumer berikutnya
+	<-started
+	cancel()
+	waitDone(t, done)
+
+	if got := session.markedOffsets(); len(got) != 0 {
+		t.Fatalf("marked offsets %v after shutdown, want none", got)
+	}
+}
+
+func TestConsumerGroupHandlerWaitsForRetryAt(t *testing.T) {
+	handler := NewConsumerGroupHandler(map[string]ConsumerHandler{
+		RetryTopic(TopicCardMoved, 1): func(ctx context.Context, message *sarama.ConsumerMessage) error {
+			t.Error("message handled before its retry time")
+			return nil
+		},
+	}, nil, RetryPolicy{Attempts: 1}, newTestLogger())
+
+	message := testMessage(RetryTopic(TopicCardMoved, 1), 0)
+	message.Headers = []*sarama.RecordHeader{
+		{Key: []byte(HeaderRetryAt), Value: []byte(time.Now().Add(time.Hour).UTC().Format(time.RFC3339Nano))},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	session := &fakeSession{ctx: ctx}
+	done := consumeClaim(handler, session, newFakeClaim(message.Topic, message))
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	waitDone(t, done)
+
+	if got := session.markedOffsets(); len(got) != 0 {
+		t.Fatalf("marked offsets %v, want none", got)
+	}
+}
+
+type fakeConsumerGroup struct {
+	sarama.ConsumerGroup
+	mu     sync.Mutex
+	topics []string
+	calls  int
+	// errs dikembalikan berurutan oleh Consume sebelum session berjalan normal
+	errs []error
+}
+
+// Consume meniru rebalance: setiap session selesai begitu ctx dibatalkan atau setelah sebentar
+func (g *fakeConsumerGroup) Consume(ctx context.Context, topics []string, handler sarama.ConsumerGroupHandler) error {
+	g.mu.Lock()
+	g.topics = topics
+	g.calls++
+	if len(g.errs) > 0 {
+		err := g.errs[0]
+		g.errs = g.errs[1:]
+		g.mu.Unlock()
+		return err
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Millisecond):
+	}
+	return nil
+}
+
+func TestConsumeTopicsStopsOnCancel(t *testing.T) {
+	// Broker yang sempat tidak bisa dihubungi tidak menghentikan consume
+	group := &fakeConsumerGroup{errs: []error{sarama.ErrOutOfBrokers}}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- ConsumeTopics(ctx, group, []string{TopicCardMoved, TopicCardClosed}, time.Millisecond, newTestLogger(), &ConsumerGroupHandler{})
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("ConsumeTopics returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ConsumeTopics did not return after ctx was cancelled")
+	}
+
+	group.mu.Lock()
+	defer group.mu.Unlock()
+	if group.calls < 3 {
+		t.Fatalf("Consume called %d times, want it called again after the error and a rebalance", group.calls)
+	}
+	if len(group.topics) != 2 {
+		t.Fatalf("subscribed to %v, want both topics in one group", group.topics)
+	}
+}
+
+func TestConsumeTopicsReturnsWhenGroupIsClosed(t *testing.T) {
+	group := &fakeConsumerGroup{errs: []error{sarama.ErrClosedConsumerGroup}}
+
+	err := ConsumeTopics(context.Background(), group, []string{TopicCardMoved}, time.Millisecond, newTestLogger(), &ConsumerGroupHandler{})
+	if !errors.Is(err, sarama.ErrClosedConsumerGroup) {
+		t.Fatalf("ConsumeTopics returned %v, want %v", err, sarama.ErrClosedConsumerGroup)
+	}
+}
+
+func TestConsumerGroupHandlerTopics(t *testing.T) {
+	handler := NewConsumerGroupHandler(map[string]ConsumerHandler{
+		TopicUserRegistered:           nil,
+		TopicCardMoved:                nil,
+		RetryTopic(TopicCardMoved, 1): nil,
+	}, nil, RetryPolicy{}, newTestLogger())
+
+	topics := handler.Topics()
+	want := []string{TopicCardMoved, RetryTopic(TopicCardMoved, 1), TopicUserRegistered}
+	if len(topics) != len(want) {
+		t.Fatalf("topics %v, want %v", topics, want)
+	}
+	for i := range want {
+		if topics[i] != want[i] {
+			t.Fatalf("topics %v, want %v", topics, want)
+		}
+	}
+}
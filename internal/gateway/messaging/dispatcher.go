@@ -0,0 +1,49 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IBM/sarama"
+	"github.com/sirupsen/logrus"
+)
+
+// VersionedConsumer consumes one type and version of event, EventConsumer implements it
+type VersionedConsumer interface {
+	EventType() string
+	EventVersion() int
+	Consume(ctx context.Context, message *sarama.ConsumerMessage) error
+}
+
+// EventDispatcher sends every message of a topic to the consumer of its event type and version,
+// so an old and a new version of an event can be consumed side by side while producers are upgraded.
+// A message without a consumer goes to the dead-letter topic and can be replayed once the worker knows it.
+type EventDispatcher struct {
+	Log       *logrus.Logger
+	Consumers map[string]VersionedConsumer
+}
+
+func NewEventDispatcher(log *logrus.Logger, consumers ...VersionedConsumer) *EventDispatcher {
+	consumerByVersion := make(map[string]VersionedConsumer, len(consumers))
+	for _, consumer := range consumers {
+		consumerByVersion[dispatchKey(consumer.EventType(), consumer.EventVersion())] = consumer
+	}
+
+	return &EventDispatcher{
+		Log:       log,
+		Consumers: consumerByVersion,
+	}
+}
+
+func (d *EventDispatcher) Consume(ctx context.Context, message *sarama.ConsumerMessage) error {
+	envelope := EnvelopeOf(message)
+	consumer, ok := d.Consumers[dispatchKey(envelope.Type, envelope.Version)]
+	if !ok {
+		return Permanent(fmt.Errorf("no consumer for event type %s version %d", envelope.Type, envelope.Version))
+	}
+	return consumer.Consume(ctx, message)
+}
+
+func dispatchKey(eventType string, version int) string {
+	return fmt.Sprintf("%s/v%d", eventType, version)
+}
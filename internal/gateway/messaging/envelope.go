@@ -0,0 +1,84 @@
+package messaging
+
+import (
+	"strconv"
+	"time"
+	"todo-app/internal/model"
+
+	"github.com/IBM/sarama"
+	"github.com/google/uuid"
+)
+
+// Header envelope mengikuti CloudEvents kafka binding (binary mode), body message berisi event itu sendiri
+const (
+	HeaderSpecVersion   = "ce_specversion"
+	HeaderId            = "ce_id"
+	HeaderSource        = "ce_source"
+	HeaderType          = "ce_type"
+	HeaderSchemaVersion = "ce_schemaversion"
+	HeaderSubject       = "ce_subject"
+	HeaderTime          = "ce_time"
+	HeaderCorrelationId = "ce_correlationid"
+	HeaderContentType   = "content-type"
+)
+
+// NewEnvelope describes an event that is published right away, outside the outbox
+func NewEnvelope(event model.Event) *model.EventEnvelope {
+	return &model.EventEnvelope{
+		SpecVersion:     model.EventSpecVersion,
+		ID:              uuid.NewString(),
+		Source:          model.EventSource,
+		Type:            event.GetEventType(),
+		Version:         event.GetEventVersion(),
+		Subject:         event.GetId(),
+		Time:            time.Now(),
+		DataContentType: model.EventContentType,
+	}
+}
+
+func envelopeHeaders(envelope *model.EventEnvelope) []sarama.RecordHeader {
+	headers := []sarama.RecordHeader{
+		{Key: []byte(HeaderSpecVersion), Value: []byte(envelope.SpecVersion)},
+		{Key: []byte(HeaderId), Value: []byte(envelope.ID)},
+		{Key: []byte(HeaderSource), Value: []byte(envelope.Source)},
+		{Key: []byte(HeaderType), Value: []byte(envelope.Type)},
+		{Key: []byte(HeaderSchemaVersion), Value: []byte(strconv.Itoa(envelope.Version))},
+		{Key: []byte(HeaderSubject), Value: []byte(envelope.Subject)},
+		{Key: []byte(HeaderTime), Value: []byte(envelope.Time.UTC().Format(time.RFC3339Nano))},
+		{Key: []byte(HeaderContentType), Value: []byte(envelope.DataContentType)},
+	}
+	if envelope.CorrelationId != "" {
+		headers = append(headers, sarama.RecordHeader{Key: []byte(HeaderCorrelationId), Value: []byte(envelope.CorrelationId)})
+	}
+	return headers
+}
+
+// EnvelopeOf reads the envelope from the message headers. A message published before the envelope existed
+// has no headers, it is read as version 1 of the event type named after its topic and has no id.
+func EnvelopeOf(message *sarama.ConsumerMessage) *model.EventEnvelope {
+	envelope := &model.EventEnvelope{
+		SpecVersion:     headerValue(message, HeaderSpecVersion),
+		ID:              headerValue(message, HeaderId),
+		Source:          headerValue(message, HeaderSource),
+		Type:            headerValue(message, HeaderType),
+		Subject:         headerValue(message, HeaderSubject),
+		CorrelationId:   headerValue(message, HeaderCorrelationId),
+		DataContentType: headerValue(message, HeaderContentType),
+	}
+
+	if envelope.Type == "" {
+		envelope.Type = originalTopic(message)
+	}
+	envelope.Version, _ = strconv.Atoi(headerValue(message, HeaderSchemaVersion))
+	if envelope.Version == 0 {
+		envelope.Version = 1
+	}
+	if envelope.Subject == "" {
+		envelope.Subject = string(message.Key)
+	}
+	envelope.Time, _ = time.Parse(time.RFC3339Nano, headerValue(message, HeaderTime))
+	if envelope.Time.IsZero() {
+		envelope.Time = message.Timestamp
+	}
+	return envelope
+}
@@ -0,0 +1,99 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"todo-app/internal/model"
+	"todo-app/internal/repository"
+
+	"github.com/IBM/sarama"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// EventPointer is the pointer to an event struct, the consumer decodes every message into a new one
+type EventPointer[T any] interface {
+	*T
+	model.Event
+}
+
+// EventConsumer decodes the message into one type and version of event and runs the handler at most once
+// per envelope id. The processed event is stored in the transaction that is passed to the handler, so the
+// database side effects of the handler and the dedup record commit or roll back together.
+type EventConsumer[T any, P EventPointer[T]] struct {
+	Name                     string
+	DB                       *gorm.DB
+	Log                      *logrus.Logger
+	ProcessedEventRepository *repository.ProcessedEventRepository
+	Handler                  func(ctx context.Context, tx *gorm.DB, event P) error
+}
+
+func NewEventConsumer[T any, P EventPointer[T]](name string, db *gorm.DB, log *logrus.Logger,
+	processedEventRepository *repository.ProcessedEventRepository, handler func(ctx context.Context, tx *gorm.DB, event P) error) *EventConsumer[T, P] {
+	return &EventConsumer[T, P]{
+		Name:                     name,
+		DB:                       db,
+		Log:                      log,
+		ProcessedEventRepository: processedEventRepository,
+		Handler:                  handler,
+	}
+}
+
+func (c *EventConsumer[T, P]) EventType() string {
+	return P(new(T)).GetEventType()
+}
+
+func (c *EventConsumer[T, P]) EventVersion() int {
+	return P(new(T)).GetEventVersion()
+}
+
+func (c *EventConsumer[T, P]) Consume(ctx context.Context, message *sarama.ConsumerMessage) error {
+	envelope := EnvelopeOf(message)
+	if envelope.Type != c.EventType() || envelope.Version != c.EventVersion() {
+		return Permanent(fmt.Errorf("%s cannot consume event type %s version %d", c.Name, envelope.Type, envelope.Version))
+	}
+
+	event := P(new(T))
+	if err := json.Unmarshal(message.Value, event); err != nil {
+		c.Log.WithError(err).Errorf("error unmarshalling event for %s", c.Name)
+		return Permanent(err)
+	}
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	eventId := eventIdOf(message, envelope)
+	claimed, err := c.ProcessedEventRepository.Claim(tx, c.Name, eventId)
+	if err != nil {
+		c.Log.WithError(err).Error("error claiming processed event")
+		return err
+	}
+	if !claimed {
+		c.Log.Debugf("Skip event %s for %s, it was already processed (correlation id %s)", eventId, c.Name, envelope.CorrelationId)
+		return nil
+	}
+
+	if err := c.Handler(ctx, tx, event); err != nil {
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error committing processed event")
+		return err
+	}
+	return nil
+}
+
+// eventIdOf memakai id dari envelope, message lama tanpa envelope memakai posisi message di topic asalnya
+func eventIdOf(message *sarama.ConsumerMessage, envelope *model.EventEnvelope) string {
+	if envelope.ID != "" {
+		return envelope.ID
+	}
+
+	partition, offset := headerValue(message, HeaderOriginalPartition), headerValue(message, HeaderOriginalOffset)
+	if partition == "" || offset == "" {
+		partition, offset = fmt.Sprint(message.Partition), fmt.Sprint(message.Offset)
+	}
+	return fmt.Sprintf("%s/%s/%s", originalTopic(message), partition, offset)
+}
@@ -0,0 +1,144 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+	"todo-app/internal/repository"
+
+	"github.com/IBM/sarama"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDB membuka database sqlite baru berisi tabel processed_events dan tabel yang ditulis handler
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := filepath.Join(t.TempDir(), "test.db") + "?_busy_timeout=5000&_journal_mode=WAL"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&entity.ProcessedEvent{}, &entity.Department{}); err != nil {
+		t.Fatalf("migrate sqlite: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+func userRegisteredMessage(t *testing.T, envelopeId string) *sarama.ConsumerMessage {
+	t.Helper()
+	event := &model.UserRegisteredEvent{ID: "user-1", Email: "jane@example.com", Name: "Jane"}
+	value, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	envelope := NewEnvelope(event)
+	envelope.ID = envelopeId
+	message := &sarama.ConsumerMessage{Topic: TopicUserRegistered, Key: []byte(event.ID), Value: value}
+	for _, header := range envelopeHeaders(envelope) {
+		message.Headers = append(message.Headers, &header)
+	}
+	return message
+}
+
+// departmentHandler menulis satu department per event, supaya commit bersama catatan deduplikasi bisa dicek
+func departmentHandler(calls *int, result error) func(ctx context.Context, tx *gorm.DB, event *model.UserRegisteredEvent) error {
+	return func(ctx context.Context, tx *gorm.DB, event *model.UserRegisteredEvent) error {
+		*calls++
+		if err := tx.Create(&entity.Department{ID: event.ID, Name: event.Name}).Error; err != nil {
+			return err
+		}
+		return result
+	}
+}
+
+func countRows(t *testing.T, db *gorm.DB, value any) int64 {
+	t.Helper()
+	var total int64
+	if err := db.Model(value).Count(&total).Error; err != nil {
+		t.Fatal(err)
+	}
+	return total
+}
+
+func TestEventConsumerSkipsRedeliveredEnvelope(t *testing.T) {
+	db := newTestDB(t)
+	calls := 0
+	consumer := NewEventConsumer("user-registered-test", db, newTestLogger(), repository.NewProcessedEventRepository(newTestLogger()),
+		departmentHandler(&calls, nil))
+
+	message := userRegisteredMessage(t, "event-1")
+	for range 2 {
+		if err := consumer.Consume(context.Background(), message); err != nil {
+			t.Fatalf("Consume returned %v", err)
+		}
+	}
+
+	if calls != 1 {
+		t.Fatalf("handler ran %d times, want once", calls)
+	}
+	if total := countRows(t, db, new(entity.ProcessedEvent)); total != 1 {
+		t.Fatalf("%d processed events, want 1", total)
+	}
+
+}
+
+func TestEventConsumerRollsBackClaimOnHandlerError(t *testing.T) {
+	db := newTestDB(t)
+	calls := 0
+	handlerErr := errors.New("mail server unavailable")
+	consumer := NewEventConsumer("user-registered-test", db, newTestLogger(), repository.NewProcessedEventRepository(newTestLogger()),
+		departmentHandler(&calls, handlerErr))
+
+	message := userRegisteredMessage(t, "event-1")
+	if err := consumer.Consume(context.Background(), message); !errors.Is(err, handlerErr) {
+		t.Fatalf("Consume returned %v, want the handler error", err)
+	}
+	if total := countRows(t, db, new(entity.ProcessedEvent)); total != 0 {
+		t.Fatalf("%d processed events after a handler error, want 0", total)
+	}
+	if total := countRows(t, db, new(entity.Department)); total != 0 {
+		t.Fatalf("%d departments after a handler error, want 0", total)
+	}
+
+	// Event yang gagal diproses ulang saat dikirim lagi
+	consumer.Handler = departmentHandler(&calls, nil)
+	if err := consumer.Consume(context.Background(), message); err != nil {
+		t.Fatalf("Consume returned %v on retry", err)
+	}
+	if calls != 2 {
+		t.Fatalf("handler ran %d times, want twice", calls)
+	}
+}
+
+func TestEventConsumerCommitsHandlerWritesWithClaim(t *testing.T) {
+	db := newTestDB(t)
+	calls := 0
+	consumer := NewEventConsumer("user-registered-test", db, newTestLogger(), repository.NewProcessedEventRepository(newTestLogger()),
+		departmentHandler(&calls, nil))
+
+	if err := consumer.Consume(context.Background(), userRegisteredMessage(t, "event-1")); err != nil {
+		t.Fatalf("Consume returned %v", err)
+	}
+
+	processed := new(entity.ProcessedEvent)
+	if err := db.Where("consumer = ? AND event_id = ?", "user-registered-test", "event-1").Take(processed).Error; err != nil {
+		t.Fatalf("processed event is not stored: %v", err)
+	}
+	department := new(entity.Department)
+	if err := db.Where("id = ?", "user-1").Take(department).Error; err != nil {
+		t.Fatalf("handler write is not committed: %v", err)
+	}
+}
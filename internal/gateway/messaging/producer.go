@@ -8,6 +8,12 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// EnvelopeSender is implemented by every Producer, the outbox relay sends events that are already marshalled
+type EnvelopeSender interface {
+	GetTopic() *string
+	SendEnvelope(envelope *model.EventEnvelope, data []byte) error
+}
+
 type Producer[T model.Event] struct {
 	Producer sarama.SyncProducer
 	Topic    string
@@ -25,10 +31,16 @@ func (p *Producer[T]) Send(event T) error {
 		return err
 	}
 
+	return p.SendEnvelope(NewEnvelope(event), value)
+}
+
+// SendEnvelope sends the envelope as headers and data as the body, keyed by the subject of the envelope
+func (p *Producer[T]) SendEnvelope(envelope *model.EventEnvelope, data []byte) error {
 	message := &sarama.ProducerMessage{
-		Topic: p.Topic,
-		Key:   sarama.StringEncoder(event.GetId()),
-		Value: sarama.ByteEncoder(value),
+		Topic:   p.Topic,
+		Key:     sarama.StringEncoder(envelope.Subject),
+		Value:   sarama.ByteEncoder(data),
+		Headers: envelopeHeaders(envelope),
 	}
 
 	partition, offset, err := p.Producer.SendMessage(message)
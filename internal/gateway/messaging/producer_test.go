@@ -0,0 +1,141 @@
+package messaging
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+	"todo-app/internal/model"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+)
+
+func producerHeader(message *sarama.ProducerMessage, key string) string {
+	for _, header := range message.Headers {
+		if string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+func encoded(encoder sarama.Encoder) string {
+	value, _ := encoder.Encode()
+	return string(value)
+}
+
+func TestProducerSendsEnvelope(t *testing.T) {
+	event := &model.CardMovedEvent{
+		ID:              "card-1",
+		ProjectId:       "project-1",
+		PreviousBoardId: "board-1",
+		BoardId:         "board-2",
+		MovedBy:         "user-1",
+		MovedAt:         time.Date(2025, 10, 22, 8, 0, 0, 0, time.UTC),
+	}
+
+	syncProducer := mocks.NewSyncProducer(t, nil)
+	syncProducer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(message *sarama.ProducerMessage) error {
+		if message.Topic != TopicCardMoved {
+			return fmt.Errorf("topic %s, want %s", message.Topic, TopicCardMoved)
+		}
+		if key := encoded(message.Key); key != event.ID {
+			return fmt.Errorf("key %s, want the card id", key)
+		}
+
+		sent := new(model.CardMovedEvent)
+		if err := json.Unmarshal([]byte(encoded(message.Value)), sent); err != nil {
+			return err
+		}
+		if *sent != *event {
+			return fmt.Errorf("body %+v, want %+v", sent, event)
+		}
+
+		headers := map[string]string{
+			HeaderSpecVersion:   model.EventSpecVersion,
+			HeaderSource:        model.EventSource,
+			HeaderType:          model.EventTypeCardMoved,
+			HeaderSchemaVersion: strconv.Itoa(event.GetEventVersion()),
+			HeaderSubject:       event.ID,
+			HeaderContentType:   model.EventContentType,
+		}
+		for key, want := range headers {
+			if got := producerHeader(message, key); got != want {
+				return fmt.Errorf("header %s is %q, want %q", key, got, want)
+			}
+		}
+		if producerHeader(message, HeaderId) == "" {
+			return errors.New("header ce_id is empty")
+		}
+		if _, err := time.Parse(time.RFC3339Nano, producerHeader(message, HeaderTime)); err != nil {
+			return fmt.Errorf("header ce_time: %w", err)
+		}
+		return nil
+	})
+	defer syncProducer.Close()
+
+	producer := NewCardMovedProducer(syncProducer, newTestLogger())
+	if err := producer.Send(event); err != nil {
+		t.Fatalf("Send returned %v", err)
+	}
+}
+
+func TestProducerSendEnvelopeKeepsOutboxEnvelope(t *testing.T) {
+	envelope := &model.EventEnvelope{
+		SpecVersion:     model.EventSpecVersion,
+		ID:              "event-1",
+		Source:          model.EventSource,
+		Type:            model.EventTypeCardClosed,
+		Version:         1,
+		Subject:         "card-1",
+		Time:            time.Date(2025, 10, 22, 8, 0, 0, 0, time.UTC),
+		CorrelationId:   "request-1",
+		DataContentType: model.EventContentType,
+	}
+	data := []byte(`{"id":"card-1"}`)
+
+	syncProducer := mocks.NewSyncProducer(t, nil)
+	syncProducer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(message *sarama.ProducerMessage) error {
+		if key := encoded(message.Key); key != envelope.Subject {
+			return fmt.Errorf("key %s, want %s", key, envelope.Subject)
+		}
+		if body := encoded(message.Value); body != string(data) {
+			return fmt.Errorf("body %s, want %s", body, data)
+		}
+
+		headers := map[string]string{
+			HeaderId:            envelope.ID,
+			HeaderType:          envelope.Type,
+			HeaderSchemaVersion: "1",
+			HeaderTime:          "2025-10-22T08:00:00Z",
+			HeaderCorrelationId: envelope.CorrelationId,
+		}
+		for key, want := range headers {
+			if got := producerHeader(message, key); got != want {
+				return fmt.Errorf("header %s is %q, want %q", key, got, want)
+			}
+		}
+		return nil
+	})
+	defer syncProducer.Close()
+
+	var sender EnvelopeSender = NewCardClosedProducer(syncProducer, newTestLogger())
+	if err := sender.SendEnvelope(envelope, data); err != nil {
+		t.Fatalf("SendEnvelope returned %v", err)
+	}
+}
+
+func TestProducerReturnsSendError(t *testing.T) {
+	syncProducer := mocks.NewSyncProducer(t, nil)
+	syncProducer.ExpectSendMessageAndFail(sarama.ErrNotLeaderForPartition)
+	defer syncProducer.Close()
+
+	producer := NewUserRegisteredProducer(syncProducer, newTestLogger())
+	err := producer.Send(&model.UserRegisteredEvent{ID: "user-1"})
+	if !errors.Is(err, sarama.ErrNotLeaderForPartition) {
+		t.Fatalf("Send returned %v, want %v", err, sarama.ErrNotLeaderForPartition)
+	}
+}
@@ -0,0 +1,148 @@
+package messaging
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+
+	"github.com/IBM/sarama"
+	"github.com/sirupsen/logrus"
+)
+
+// DeadLetterReplayer sends the messages of a dead-letter topic back to their original topic.
+// It reads every partition up to the offset it had when the claim started and then stops,
+// the replayed messages are marked so the next run only replays new dead letters.
+// The consumer group session ends as soon as one claim returns, so a finished claim waits
+// until every claim of the session is finished.
+type DeadLetterReplayer struct {
+	Producer sarama.SyncProducer
+	Log      *logrus.Logger
+	// DryRun hanya menampilkan message tanpa mengirim ulang atau menandainya
+	DryRun bool
+	// Partition dibaca bersamaan, jumlahnya dihitung secara atomic
+	Replayed atomic.Int64
+	Failed   atomic.Int64
+
+	remaining atomic.Int64
+	finished  atomic.Bool
+	cancel    context.CancelFunc
+}
+
+func NewDeadLetterReplayer(producer sarama.SyncProducer, log *logrus.Logger, dryRun bool) *DeadLetterReplayer {
+	return &DeadLetterReplayer{
+		Producer: producer,
+		Log:      log,
+		DryRun:   dryRun,
+	}
+}
+
+// Replay consumes the dead-letter topic until every partition is replayed or ctx is cancelled
+func (r *DeadLetterReplayer) Replay(ctx context.Context, consumerGroup sarama.ConsumerGroup, deadLetterTopic string) error {
+	ctx, r.cancel = context.WithCancel(ctx)
+	defer r.cancel()
+
+	// Consume berhenti juga saat rebalance, partition yang belum selesai dilanjutkan di session berikutnya
+	for !r.finished.Load() && ctx.Err() == nil {
+		if err := consumerGroup.Consume(ctx, []string{deadLetterTopic}, r); err != nil {
+			if r.finished.Load() || ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *DeadLetterReplayer) Setup(session sarama.ConsumerGroupSession) error {
+	claims := 0
+	for _, partitions := range session.Claims() {
+		claims += len(partitions)
+	}
+	r.remaining.Store(int64(claims))
+	return nil
+}
+
+func (r *DeadLetterReplayer) Cleanup(session sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (r *DeadLetterReplayer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	if err := r.consumeUntil(session, claim, claim.HighWaterMarkOffset()); err != nil {
+		// Message ini tidak di-mark, run berikutnya mengulang dari message ini
+		r.Failed.Add(1)
+	}
+
+	if r.remaining.Add(-1) == 0 && session.Context().Err() == nil {
+		r.finished.Store(true)
+		r.cancel()
+	}
+	<-session.Context().Done()
+	return nil
+}
+
+func (r *DeadLetterReplayer) consumeUntil(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim, highWaterMark int64) error {
+	if claim.InitialOffset() >= highWaterMark {
+		return nil
+	}
+
+	for {
+		select {
+		case message, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+			if err := r.replay(message); err != nil {
+				return err
+			}
+			if !r.DryRun {
+				session.MarkMessage(message, "")
+			}
+			if message.Offset+1 >= highWaterMark {
+				return nil
+			}
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+}
+
+func (r *DeadLetterReplayer) replay(message *sarama.ConsumerMessage) error {
+	topic := headerValue(message, HeaderOriginalTopic)
+	if topic == "" {
+		topic = strings.TrimSuffix(message.Topic, ".dlq")
+	}
+
+	r.Log.WithFields(logrus.Fields{
+		"key":       string(message.Key),
+		"error":     headerValue(message, HeaderError),
+		"failed_at": headerValue(message, HeaderFailedAt),
+	}).Infof("Replay message %s/%d/%d to topic %s", message.Topic, message.Partition, message.Offset, topic)
+	if r.DryRun {
+		return nil
+	}
+
+	// Metadata retry dibuang supaya message mendapat jatah retry baru di topic asal
+	headers := make([]sarama.RecordHeader, 0, len(message.Headers)+1)
+	for _, header := range message.Headers {
+		switch string(header.Key) {
+		case HeaderOriginalTopic, HeaderOriginalPartition, HeaderOriginalOffset,
+			HeaderRetryStage, HeaderRetryAt, HeaderError, HeaderFailedAt, HeaderReplayedFrom:
+			continue
+		}
+		headers = append(headers, *header)
+	}
+	headers = append(headers, sarama.RecordHeader{Key: []byte(HeaderReplayedFrom), Value: []byte(message.Topic)})
+
+	if _, _, err := r.Producer.SendMessage(&sarama.ProducerMessage{
+		Topic:   topic,
+		Key:     sarama.ByteEncoder(message.Key),
+		Value:   sarama.ByteEncoder(message.Value),
+		Headers: headers,
+	}); err != nil {
+		r.Log.WithError(err).Errorf("Failed to replay message to topic %s", topic)
+		return err
+	}
+
+	r.Replayed.Add(1)
+	return nil
+}
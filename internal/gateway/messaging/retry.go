@@ -0,0 +1,165 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// Header yang ditambahkan saat message dipindah ke retry topic atau dead-letter topic
+const (
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+	HeaderRetryStage        = "x-retry-stage"
+	HeaderRetryAt           = "x-retry-at"
+	HeaderError             = "x-error"
+	HeaderFailedAt          = "x-failed-at"
+	HeaderReplayedFrom      = "x-replayed-from"
+)
+
+// RetryPolicy decides how often a failed message is tried again before it is moved to the dead-letter topic.
+// Attempts are made in process with an exponential Backoff, after that the message goes through
+// RetryTopics retry topics, stage n is processed again RetryDelay * n after it failed.
+type RetryPolicy struct {
+	Attempts    int
+	Backoff     time.Duration
+	RetryTopics int
+	RetryDelay  time.Duration
+}
+
+// RetryTopic is the topic of retry stage n for a topic, e.g. card.moved.retry.1
+func RetryTopic(topic string, stage int) string {
+	return fmt.Sprintf("%s.retry.%d", topic, stage)
+}
+
+// RetryTopicsOf returns the retry topic chain of a topic in the order a message goes through it
+func RetryTopicsOf(topic string, policy RetryPolicy) []string {
+	topics := make([]string, 0, policy.RetryTopics)
+	for stage := 1; stage <= policy.RetryTopics; stage++ {
+		topics = append(topics, RetryTopic(topic, stage))
+	}
+	return topics
+}
+
+// DeadLetterTopic is the topic of messages that failed every retry, e.g. card.moved.dlq
+func DeadLetterTopic(topic string) string {
+	return topic + ".dlq"
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks an error that will not go away on a retry, e.g. a message that cannot be decoded.
+// The message is moved to the dead-letter topic right away.
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
+
+func headerValue(message *sarama.ConsumerMessage, key string) string {
+	for _, header := range message.Headers {
+		if header != nil && string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+// retryStage mengembalikan stage retry topic dari message, 0 untuk message dari topic asal
+func retryStage(message *sarama.ConsumerMessage) int {
+	stage, _ := strconv.Atoi(headerValue(message, HeaderRetryStage))
+	return stage
+}
+
+// originalTopic mengembalikan topic tempat message pertama kali dikirim
+func originalTopic(message *sarama.ConsumerMessage) string {
+	if topic := headerValue(message, HeaderOriginalTopic); topic != "" {
+		return topic
+	}
+	return message.Topic
+}
+
+// waitRetryAt menahan message dari retry topic sampai waktu retry-nya, false jika ctx selesai lebih dulu
+func waitRetryAt(ctx context.Context, message *sarama.ConsumerMessage) bool {
+	retryAt, err := time.Parse(time.RFC3339Nano, headerValue(message, HeaderRetryAt))
+	if err != nil {
+		return ctx.Err() == nil
+	}
+	return sleep(ctx, time.Until(retryAt))
+}
+
+// sleep menunggu selama duration, false jika ctx selesai lebih dulu
+func sleep(ctx context.Context, duration time.Duration) bool {
+	if duration <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// failedMessage builds the message for the next retry topic, or the dead-letter topic when the retries are used up.
+// The original topic, partition and offset are kept from the first failure.
+func failedMessage(message *sarama.ConsumerMessage, policy RetryPolicy, cause error, now time.Time) *sarama.ProducerMessage {
+	topic := originalTopic(message)
+	stage := retryStage(message) + 1
+
+	headers := make([]sarama.RecordHeader, 0, len(message.Headers)+7)
+	for _, header := range message.Headers {
+		switch string(header.Key) {
+		case HeaderRetryStage, HeaderRetryAt, HeaderError, HeaderFailedAt:
+			continue
+		}
+		headers = append(headers, *header)
+	}
+	if headerValue(message, HeaderOriginalTopic) == "" {
+		headers = append(headers,
+			sarama.RecordHeader{Key: []byte(HeaderOriginalTopic), Value: []byte(message.Topic)},
+			sarama.RecordHeader{Key: []byte(HeaderOriginalPartition), Value: []byte(strconv.Itoa(int(message.Partition)))},
+			sarama.RecordHeader{Key: []byte(HeaderOriginalOffset), Value: []byte(strconv.FormatInt(message.Offset, 10))},
+		)
+	}
+	headers = append(headers,
+		sarama.RecordHeader{Key: []byte(HeaderRetryStage), Value: []byte(strconv.Itoa(stage))},
+		sarama.RecordHeader{Key: []byte(HeaderError), Value: []byte(cause.Error())},
+		sarama.RecordHeader{Key: []byte(HeaderFailedAt), Value: []byte(now.UTC().Format(time.RFC3339Nano))},
+	)
+
+	target := DeadLetterTopic(topic)
+	if !IsPermanent(cause) && stage <= policy.RetryTopics {
+		target = RetryTopic(topic, stage)
+		retryAt := now.Add(policy.RetryDelay * time.Duration(stage))
+		headers = append(headers, sarama.RecordHeader{Key: []byte(HeaderRetryAt), Value: []byte(retryAt.UTC().Format(time.RFC3339Nano))})
+	}
+
+	return &sarama.ProducerMessage{
+		Topic:   target,
+		Key:     sarama.ByteEncoder(message.Key),
+		Value:   sarama.ByteEncoder(message.Value),
+		Headers: headers,
+	}
+}
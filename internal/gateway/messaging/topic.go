@@ -0,0 +1,12 @@
+package messaging
+
+// Topic yang dipakai producer di web dan consumer di worker
+const (
+	TopicUserRegistered = "user.registered"
+	TopicRoleChanged    = "user.role_changed"
+	TopicCardMoved      = "card.moved"
+	TopicCardClosed     = "card.closed"
+)
+
+// Topics lists every topic the outbox relay has a producer for, an event for another topic would never be sent
+var Topics = []string{TopicUserRegistered, TopicRoleChanged, TopicCardMoved, TopicCardClosed}
@@ -0,0 +1,56 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"todo-app/internal/gateway/mail"
+	"todo-app/internal/model"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// UserRegisteredConsumer sends the welcome notification to a newly registered user
+type UserRegisteredConsumer struct {
+	Log    *logrus.Logger
+	Mailer mail.Mailer
+}
+
+func NewUserRegisteredConsumer(log *logrus.Logger, mailer mail.Mailer) *UserRegisteredConsumer {
+	return &UserRegisteredConsumer{
+		Log:    log,
+		Mailer: mailer,
+	}
+}
+
+func (c *UserRegisteredConsumer) Handle(ctx context.Context, tx *gorm.DB, event *model.UserRegisteredEvent) error {
+	return c.Mailer.Send(ctx, &mail.Message{
+		To:      event.Email,
+		Subject: "Selamat datang",
+		Body:    fmt.Sprintf("Halo %s,\n\nAkun kamu sudah berhasil dibuat.\n", event.Name),
+	})
+}
+
+// RoleChangedConsumer is the audit sink for role changes, it writes one structured log entry per event
+// so the log pipeline can ship it to long term storage
+type RoleChangedConsumer struct {
+	Log *logrus.Logger
+}
+
+func NewRoleChangedConsumer(log *logrus.Logger) *RoleChangedConsumer {
+	return &RoleChangedConsumer{
+		Log: log,
+	}
+}
+
+func (c *RoleChangedConsumer) Handle(ctx context.Context, tx *gorm.DB, event *model.RoleChangedEvent) error {
+	c.Log.WithFields(logrus.Fields{
+		"audit":            true,
+		"user_id":          event.ID,
+		"previous_role_id": event.PreviousRoleId,
+		"role_id":          event.RoleId,
+		"changed_by":       event.ChangedBy,
+		"changed_at":       event.ChangedAt,
+	}).Info("user role changed")
+	return nil
+}
@@ -0,0 +1,36 @@
+package messaging
+
+import (
+	"todo-app/internal/model"
+
+	"github.com/IBM/sarama"
+	"github.com/sirupsen/logrus"
+)
+
+type UserRegisteredProducer struct {
+	Producer[*model.UserRegisteredEvent]
+}
+
+func NewUserRegisteredProducer(producer sarama.SyncProducer, log *logrus.Logger) *UserRegisteredProducer {
+	return &UserRegisteredProducer{
+		Producer: Producer[*model.UserRegisteredEvent]{
+			Producer: producer,
+			Topic:    TopicUserRegistered,
+			Log:      log,
+		},
+	}
+}
+
+type RoleChangedProducer struct {
+	Producer[*model.RoleChangedEvent]
+}
+
+func NewRoleChangedProducer(producer sarama.SyncProducer, log *logrus.Logger) *RoleChangedProducer {
+	return &RoleChangedProducer{
+		Producer: Producer[*model.RoleChangedEvent]{
+			Producer: producer,
+			Topic:    TopicRoleChanged,
+			Log:      log,
+		},
+	}
+}
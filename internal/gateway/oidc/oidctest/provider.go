@@ -0,0 +1,182 @@
+// Package oidctest runs an in-process OpenID Connect provider for tests. It serves the discovery document,
+// the JWKS and a token endpoint that checks the PKCE code verifier, and signs id_tokens with an Ed25519 key.
+package oidctest
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+	"todo-app/internal/gateway/oidc"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const KeyId = "oidctest"
+
+type authorization struct {
+	codeChallenge string
+	nonce         string
+	claims        jwt.MapClaims
+}
+
+type Provider struct {
+	Server   *httptest.Server
+	ClientId string
+
+	privateKey ed25519.PrivateKey
+	publicKey  ed25519.PublicKey
+
+	mu    sync.Mutex
+	codes map[string]*authorization
+	// TokenRequests counts the requests to the token endpoint
+	TokenRequests int
+}
+
+// NewProvider starts the provider, the caller closes it with Close
+func NewProvider(clientId string) *Provider {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	p := &Provider{
+		ClientId:   clientId,
+		privateKey: privateKey,
+		publicKey:  publicKey,
+		codes:      make(map[string]*authorization),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("GET /jwks", p.jwks)
+	mux.HandleFunc("POST /token", p.token)
+	p.Server = httptest.NewServer(mux)
+	return p
+}
+
+func (p *Provider) Close() {
+	p.Server.Close()
+}
+
+// Issuer is the issuer url the application is configured with
+func (p *Provider) Issuer() string {
+	return p.Server.URL
+}
+
+// Authorize plays the browser and the user signing in: it reads the authorization url and remembers
+// the code challenge, nonce and the claims of the user, then returns the code and state of the redirect.
+func (p *Provider) Authorize(authorizationUrl string, claims jwt.MapClaims) (string, string, error) {
+	parsed, err := url.Parse(authorizationUrl)
+	if err != nil {
+		return "", "", err
+	}
+
+	query := parsed.Query()
+	if query.Get("response_type") != "code" || query.Get("client_id") != p.ClientId {
+		return "", "", fmt.Errorf("unexpected authorization request %s", parsed.RawQuery)
+	}
+	if query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		return "", "", errors.New("authorization request has no S256 code challenge")
+	}
+	if query.Get("state") == "" || query.Get("nonce") == "" {
+		return "", "", errors.New("authorization request has no state or nonce")
+	}
+
+	code := rand.Text()
+	p.mu.Lock()
+	p.codes[code] = &authorization{
+		codeChallenge: query.Get("code_challenge"),
+		nonce:         query.Get("nonce"),
+		claims:        claims,
+	}
+	p.mu.Unlock()
+
+	return code, query.Get("state"), nil
+}
+
+// SignIDToken signs the claims with the provider key
+func (p *Provider) SignIDToken(claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = KeyId
+	signed, err := token.SignedString(p.privateKey)
+	if err != nil {
+		panic(err)
+	}
+	return signed
+}
+
+// Claims returns valid id_token claims for subject, the caller can override any of them
+func (p *Provider) Claims(subject string, nonce string) jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":   p.Issuer(),
+		"aud":   p.ClientId,
+		"sub":   subject,
+		"nonce": nonce,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}
+}
+
+func (p *Provider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, oidc.Metadata{
+		Issuer:                        p.Issuer(),
+		AuthorizationEndpoint:         p.Issuer() + "/authorize",
+		TokenEndpoint:                 p.Issuer() + "/token",
+		JwksUri:                       p.Issuer() + "/jwks",
+		CodeChallengeMethodsSupported: []string{"S256"},
+	})
+}
+
+func (p *Provider) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, oidc.JSONWebKeySet{Keys: []oidc.JSONWebKey{{
+		Kty: "OKP",
+		Kid: KeyId,
+		Use: "sig",
+		Alg: "EdDSA",
+		Crv: "Ed25519",
+		X:   base64.RawURLEncoding.EncodeToString(p.publicKey),
+	}}})
+}
+
+// token menukar code sekali pakai, code_verifier harus cocok dengan code_challenge dari authorization request
+func (p *Provider) token(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	p.TokenRequests++
+	authorization, ok := p.codes[r.FormValue("code")]
+	delete(p.codes, r.FormValue("code"))
+	p.mu.Unlock()
+
+	if r.FormValue("grant_type") != "authorization_code" || !ok {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+	if oidc.CodeChallengeS256(r.FormValue("code_verifier")) != authorization.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant", "error_description": "code verifier mismatch"})
+		return
+	}
+
+	claims := p.Claims("", authorization.nonce)
+	maps.Copy(claims, authorization.claims)
+	writeJSON(w, http.StatusOK, oidc.TokenResponse{
+		AccessToken: rand.Text(),
+		TokenType:   "Bearer",
+		IdToken:     p.SignIDToken(claims),
+		ExpiresIn:   3600,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
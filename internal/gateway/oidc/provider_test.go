@@ -0,0 +1,158 @@
+package oidc_test
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+	"todo-app/internal/gateway/oidc"
+	"todo-app/internal/gateway/oidc/oidctest"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+)
+
+const clientId = "todo-app"
+
+func newProvider(t *testing.T) (*oidctest.Provider, *oidc.Provider) {
+	t.Helper()
+	fake := oidctest.NewProvider(clientId)
+	t.Cleanup(fake.Close)
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	provider := oidc.NewProvider(fake.Issuer(), clientId, "secret", "http://localhost:3000/callback",
+		[]string{"openid", "email", "profile"}, "groups", log)
+	return fake, provider
+}
+
+// login menjalankan authorization code flow sampai id_token diverifikasi
+func login(t *testing.T, fake *oidctest.Provider, provider *oidc.Provider, claims jwt.MapClaims) (*oidc.IDToken, error) {
+	t.Helper()
+	ctx := context.Background()
+
+	authorizationUrl, err := provider.AuthCodeURL(ctx, "state", "nonce", "verifier")
+	if err != nil {
+		t.Fatalf("AuthCodeURL returned %v", err)
+	}
+	code, _, err := fake.Authorize(authorizationUrl, claims)
+	if err != nil {
+		t.Fatalf("Authorize returned %v", err)
+	}
+
+	token, err := provider.Exchange(ctx, code, "verifier")
+	if err != nil {
+		t.Fatalf("Exchange returned %v", err)
+	}
+	return provider.VerifyIDToken(ctx, token.IdToken, "nonce")
+}
+
+func TestAuthCodeURLCarriesStateNonceAndPkce(t *testing.T) {
+	_, provider := newProvider(t)
+
+	authorizationUrl, err := provider.AuthCodeURL(context.Background(), "state-1", "nonce-1", "verifier-1")
+	if err != nil {
+		t.Fatalf("AuthCodeURL returned %v", err)
+	}
+
+	parsed, _ := url.Parse(authorizationUrl)
+	query := parsed.Query()
+	want := map[string]string{
+		"response_type":         "code",
+		"client_id":             clientId,
+		"state":                 "state-1",
+		"nonce":                 "nonce-1",
+		"code_challenge":        oidc.CodeChallengeS256("verifier-1"),
+		"code_challenge_method": "S256",
+		"scope":                 "openid email profile",
+	}
+	for key, value := range want {
+		if query.Get(key) != value {
+			t.Errorf("%s is %q, want %q", key, query.Get(key), value)
+		}
+	}
+	if query.Has("code_verifier") {
+		t.Error("authorization url leaks the code verifier")
+	}
+}
+
+func TestVerifyIDTokenReadsClaims(t *testing.T) {
+	fake, provider := newProvider(t)
+
+	idToken, err := login(t, fake, provider, jwt.MapClaims{
+		"sub":            "subject-1",
+		"email":          "jane@example.com",
+		"email_verified": "true",
+		"name":           "Jane",
+		"groups":         []string{"admins", "devs"},
+	})
+	if err != nil {
+		t.Fatalf("VerifyIDToken returned %v", err)
+	}
+
+	if idToken.Issuer != fake.Issuer() || idToken.Subject != "subject-1" || idToken.Email != "jane@example.com" ||
+		!idToken.EmailVerified || idToken.Name != "Jane" || strings.Join(idToken.Groups, ",") != "admins,devs" {
+		t.Fatalf("unexpected id token %+v", idToken)
+	}
+}
+
+func TestExchangeRejectsWrongCodeVerifier(t *testing.T) {
+	fake, provider := newProvider(t)
+	ctx := context.Background()
+
+	authorizationUrl, _ := provider.AuthCodeURL(ctx, "state", "nonce", "verifier")
+	code, _, err := fake.Authorize(authorizationUrl, jwt.MapClaims{"sub": "subject-1"})
+	if err != nil {
+		t.Fatalf("Authorize returned %v", err)
+	}
+
+	if _, err := provider.Exchange(ctx, code, "another-verifier"); err == nil {
+		t.Fatal("Exchange accepted a code verifier that does not match the challenge")
+	}
+}
+
+func TestVerifyIDTokenRejectsInvalidTokens(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims jwt.MapClaims
+	}{
+		{name: "nonce mismatch", claims: jwt.MapClaims{"nonce": "another-nonce"}},
+		{name: "missing nonce", claims: jwt.MapClaims{"nonce": ""}},
+		{name: "audience mismatch", claims: jwt.MapClaims{"aud": "another-client"}},
+		{name: "multiple audiences without azp", claims: jwt.MapClaims{"aud": []string{clientId, "another-client"}}},
+		{name: "issuer mismatch", claims: jwt.MapClaims{"iss": "https://evil.example.com"}},
+		{name: "expired", claims: jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()}},
+		{name: "missing subject", claims: jwt.MapClaims{"sub": ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, provider := newProvider(t)
+			claims := jwt.MapClaims{"sub": "subject-1"}
+			for key, value := range tt.claims {
+				claims[key] = value
+			}
+
+			if _, err := login(t, fake, provider, claims); err == nil {
+				t.Fatal("VerifyIDToken accepted the token")
+			}
+		})
+	}
+}
+
+func TestVerifyIDTokenRejectsForeignSignature(t *testing.T) {
+	fake, provider := newProvider(t)
+	other := oidctest.NewProvider(clientId)
+	defer other.Close()
+
+	// Token ditandatangani key lain dengan kid yang sama
+	claims := fake.Claims("subject-1", "nonce")
+	if _, err := provider.VerifyIDToken(context.Background(), other.SignIDToken(claims), "nonce"); err == nil {
+		t.Fatal("VerifyIDToken accepted a token signed by another key")
+	}
+	if _, err := provider.VerifyIDToken(context.Background(), fake.SignIDToken(claims), "nonce"); err != nil {
+		t.Fatalf("VerifyIDToken rejected a valid token: %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+package search
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Indexer applies partial updates to the card documents of the search index
+type Indexer interface {
+	UpdateCard(ctx context.Context, id string, fields map[string]any) error
+}
+
+// LogIndexer only writes the update to the log, used until a search engine is configured
+type LogIndexer struct {
+	Log *logrus.Logger
+}
+
+func NewLogIndexer(log *logrus.Logger) *LogIndexer {
+	return &LogIndexer{
+		Log: log,
+	}
+}
+
+func (i *LogIndexer) UpdateCard(ctx context.Context, id string, fields map[string]any) error {
+	i.Log.WithFields(logrus.Fields(fields)).Infof("Index card %s", id)
+	return nil
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditLogResponse struct {
+	ID         string          `json:"id"`
+	ActorId    string          `json:"actor_id,omitempty"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityId   string          `json:"entity_id"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	IpAddress  string          `json:"ip_address,omitempty"`
+	RequestId  string          `json:"request_id,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+type SearchAuditLogRequest struct {
+	ActorId    string     `json:"actor_id" validate:"max=100"`
+	Action     string     `json:"action" validate:"max=50"`
+	EntityType string     `json:"entity_type" validate:"max=100"`
+	EntityId   string     `json:"entity_id" validate:"max=100"`
+	RequestId  string     `json:"request_id" validate:"max=100"`
+	From       *time.Time `json:"from"`
+	To         *time.Time `json:"to"`
+	Page       int        `json:"page" validate:"min=1"`
+	Size       int        `json:"size" validate:"min=1,max=100"`
+}
@@ -1,6 +1,21 @@
 package model
 
+import "time"
+
 type Auth struct {
 	// Login user id
 	ID string
+	// Role and department of the login user
+	RoleId       string
+	DepartmentId string
+	// Session (device) the access token belongs to
+	SessionId string
+	// Role requires MFA but the user has not enrolled yet
+	MfaPending bool
+	// Request is authenticated with a personal access token limited to Scopes
+	PersonalAccessToken bool
+	Scopes              []string
+	// Id (jti) and expiry of the access token used for this request
+	TokenId   string
+	ExpiresAt time.Time
 }
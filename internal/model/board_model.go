@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+type BoardResponse struct {
+	ID        string     `json:"id"`
+	ProjectId string     `json:"project_id"`
+	Name      string     `json:"name"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+type CreateBoardRequest struct {
+	UserId    string `json:"-" validate:"required,max=100"`
+	ProjectId string `json:"-" validate:"required,max=100,uuid"`
+	Name      string `json:"name" validate:"required,max=100"`
+}
+
+type UpdateBoardRequest struct {
+	UserId    string `json:"-" validate:"required,max=100"`
+	ProjectId string `json:"-" validate:"required,max=100,uuid"`
+	ID        string `json:"-" validate:"required,max=100,uuid"`
+	Name      string `json:"name" validate:"required,max=100"`
+}
+
+type SearchBoardRequest struct {
+	UserId    string `json:"-" validate:"required,max=100"`
+	ProjectId string `json:"-" validate:"required,max=100,uuid"`
+	Name      string `json:"name" validate:"max=100"`
+	Page      int    `json:"page" validate:"min=1"`
+	Size      int    `json:"size" validate:"min=1,max=100"`
+}
+
+type GetBoardRequest struct {
+	UserId    string `json:"-" validate:"required,max=100"`
+	ProjectId string `json:"-" validate:"required,max=100,uuid"`
+	ID        string `json:"-" validate:"required,max=100,uuid"`
+}
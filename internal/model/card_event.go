@@ -0,0 +1,52 @@
+package model
+
+import "time"
+
+// Tipe event sama dengan nama topic tempat event dikirim
+const (
+	EventTypeCardMoved  = "card.moved"
+	EventTypeCardClosed = "card.closed"
+)
+
+// CardMovedEvent is published when a card is moved to another board of the same project
+type CardMovedEvent struct {
+	ID              string    `json:"id"`
+	ProjectId       string    `json:"project_id"`
+	PreviousBoardId string    `json:"previous_board_id"`
+	BoardId         string    `json:"board_id"`
+	MovedBy         string    `json:"moved_by"`
+	MovedAt         time.Time `json:"moved_at"`
+}
+
+func (e *CardMovedEvent) GetId() string {
+	return e.ID
+}
+
+func (e *CardMovedEvent) GetEventType() string {
+	return EventTypeCardMoved
+}
+
+func (e *CardMovedEvent) GetEventVersion() int {
+	return 1
+}
+
+// CardClosedEvent is published when an open card is closed
+type CardClosedEvent struct {
+	ID        string    `json:"id"`
+	ProjectId string    `json:"project_id"`
+	BoardId   string    `json:"board_id"`
+	ClosedBy  string    `json:"closed_by"`
+	ClosedAt  time.Time `json:"closed_at"`
+}
+
+func (e *CardClosedEvent) GetId() string {
+	return e.ID
+}
+
+func (e *CardClosedEvent) GetEventType() string {
+	return EventTypeCardClosed
+}
+
+func (e *CardClosedEvent) GetEventVersion() int {
+	return 1
+}
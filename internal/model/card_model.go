@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+type CardResponse struct {
+	ID        string     `json:"id"`
+	BoardId   string     `json:"board_id"`
+	UserId    *string    `json:"user_id"`
+	Name      string     `json:"name"`
+	IsClosed  bool       `json:"is_closed"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+type CreateCardRequest struct {
+	UserId     string `json:"-" validate:"required,max=100"`
+	ProjectId  string `json:"-" validate:"required,max=100,uuid"`
+	BoardId    string `json:"-" validate:"required,max=100,uuid"`
+	Name       string `json:"name" validate:"required,max=100"`
+	AssigneeId string `json:"assignee_id" validate:"omitempty,max=100"`
+}
+
+type SearchCardRequest struct {
+	UserId    string `json:"-" validate:"required,max=100"`
+	ProjectId string `json:"-" validate:"required,max=100,uuid"`
+	BoardId   string `json:"-" validate:"required,max=100,uuid"`
+	Name      string `json:"name" validate:"max=100"`
+	Page      int    `json:"page" validate:"min=1"`
+	Size      int    `json:"size" validate:"min=1,max=100"`
+}
+
+type GetCardRequest struct {
+	UserId    string `json:"-" validate:"required,max=100"`
+	ProjectId string `json:"-" validate:"required,max=100,uuid"`
+	ID        string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type MoveCardRequest struct {
+	UserId    string `json:"-" validate:"required,max=100"`
+	ProjectId string `json:"-" validate:"required,max=100,uuid"`
+	ID        string `json:"-" validate:"required,max=100,uuid"`
+	BoardId   string `json:"board_id" validate:"required,max=100,uuid"`
+}
+
+type AssignCardRequest struct {
+	UserId     string `json:"-" validate:"required,max=100"`
+	ProjectId  string `json:"-" validate:"required,max=100,uuid"`
+	ID         string `json:"-" validate:"required,max=100,uuid"`
+	AssigneeId string `json:"assignee_id" validate:"omitempty,max=100"`
+}
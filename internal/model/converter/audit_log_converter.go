@@ -0,0 +1,29 @@
+package converter
+
+import (
+	"encoding/json"
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+)
+
+func AuditLogToResponse(auditLog *entity.AuditLog) *model.AuditLogResponse {
+	response := &model.AuditLogResponse{
+		ID:         auditLog.ID,
+		Action:     auditLog.Action,
+		EntityType: auditLog.EntityType,
+		EntityId:   auditLog.EntityId,
+		IpAddress:  auditLog.IpAddress,
+		RequestId:  auditLog.RequestId,
+		CreatedAt:  auditLog.CreatedAt,
+	}
+	if auditLog.ActorId != nil {
+		response.ActorId = *auditLog.ActorId
+	}
+	if auditLog.BeforeData != nil {
+		response.Before = json.RawMessage(*auditLog.BeforeData)
+	}
+	if auditLog.AfterData != nil {
+		response.After = json.RawMessage(*auditLog.AfterData)
+	}
+	return response
+}
@@ -0,0 +1,22 @@
+package converter
+
+import (
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+)
+
+func BoardToResponse(board *entity.Board) *model.BoardResponse {
+	response := &model.BoardResponse{
+		ID:        board.ID,
+		ProjectId: board.ProjectId,
+		Name:      board.Name,
+		CreatedAt: board.CreatedAt,
+		UpdatedAt: board.UpdatedAt,
+	}
+
+	if board.DeletedAt.Valid {
+		response.DeletedAt = &board.DeletedAt.Time
+	}
+
+	return response
+}
@@ -0,0 +1,45 @@
+package converter
+
+import (
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+)
+
+func CardToResponse(card *entity.Card) *model.CardResponse {
+	response := &model.CardResponse{
+		ID:        card.ID,
+		BoardId:   card.BoardId,
+		UserId:    card.UserId,
+		Name:      card.Name,
+		IsClosed:  card.IsClosed,
+		CreatedAt: card.CreatedAt,
+		UpdatedAt: card.UpdatedAt,
+	}
+
+	if card.DeletedAt.Valid {
+		response.DeletedAt = &card.DeletedAt.Time
+	}
+
+	return response
+}
+
+func CardToMovedEvent(card *entity.Card, projectId string, previousBoardId string, movedBy string) *model.CardMovedEvent {
+	return &model.CardMovedEvent{
+		ID:              card.ID,
+		ProjectId:       projectId,
+		PreviousBoardId: previousBoardId,
+		BoardId:         card.BoardId,
+		MovedBy:         movedBy,
+		MovedAt:         card.UpdatedAt,
+	}
+}
+
+func CardToClosedEvent(card *entity.Card, projectId string, closedBy string) *model.CardClosedEvent {
+	return &model.CardClosedEvent{
+		ID:        card.ID,
+		ProjectId: projectId,
+		BoardId:   card.BoardId,
+		ClosedBy:  closedBy,
+		ClosedAt:  card.UpdatedAt,
+	}
+}
@@ -0,0 +1,21 @@
+package converter
+
+import (
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+)
+
+func DepartmentToResponse(department *entity.Department) *model.DepartmentResponse {
+	response := &model.DepartmentResponse{
+		ID:        department.ID,
+		Name:      department.Name,
+		CreatedAt: department.CreatedAt,
+		UpdatedAt: department.UpdatedAt,
+	}
+
+	if department.DeletedAt.Valid {
+		response.DeletedAt = &department.DeletedAt.Time
+	}
+
+	return response
+}
@@ -0,0 +1,41 @@
+package converter
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"todo-app/internal/model"
+	"todo-app/internal/util/helper"
+)
+
+func SigningKeysToJWKS(keys []*helper.SigningKey) *model.JSONWebKeySetResponse {
+	response := &model.JSONWebKeySetResponse{
+		Keys: make([]model.JSONWebKeyResponse, 0, len(keys)),
+	}
+
+	for _, key := range keys {
+		jwk := model.JSONWebKeyResponse{
+			Kid: key.Id,
+			Use: "sig",
+			Alg: key.Method.Alg(),
+		}
+
+		switch publicKey := key.PublicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+		default:
+			continue
+		}
+
+		response.Keys = append(response.Keys, jwk)
+	}
+
+	return response
+}
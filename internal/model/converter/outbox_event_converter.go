@@ -0,0 +1,23 @@
+package converter
+
+import (
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+)
+
+func OutboxEventToEnvelope(outboxEvent *entity.OutboxEvent) *model.EventEnvelope {
+	envelope := &model.EventEnvelope{
+		SpecVersion:     model.EventSpecVersion,
+		ID:              outboxEvent.EventId,
+		Source:          model.EventSource,
+		Type:            outboxEvent.EventType,
+		Version:         outboxEvent.EventVersion,
+		Subject:         outboxEvent.EventKey,
+		Time:            outboxEvent.CreatedAt,
+		DataContentType: model.EventContentType,
+	}
+	if outboxEvent.CorrelationId != nil {
+		envelope.CorrelationId = *outboxEvent.CorrelationId
+	}
+	return envelope
+}
@@ -0,0 +1,19 @@
+package converter
+
+import (
+	"strings"
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+)
+
+func PersonalAccessTokenToResponse(token *entity.PersonalAccessToken) *model.PersonalAccessTokenResponse {
+	return &model.PersonalAccessTokenResponse{
+		ID:         token.ID,
+		Name:       token.Name,
+		Prefix:     token.TokenPrefix,
+		Scopes:     strings.Split(token.Scopes, ","),
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		CreatedAt:  token.CreatedAt,
+	}
+}
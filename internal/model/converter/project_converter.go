@@ -0,0 +1,22 @@
+package converter
+
+import (
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+)
+
+func ProjectToResponse(project *entity.Project) *model.ProjectResponse {
+	response := &model.ProjectResponse{
+		ID:           project.ID,
+		Name:         project.Name,
+		DepartmentId: project.DepartmentId,
+		CreatedAt:    project.CreatedAt,
+		UpdatedAt:    project.UpdatedAt,
+	}
+
+	if project.DeletedAt.Valid {
+		response.DeletedAt = &project.DeletedAt.Time
+	}
+
+	return response
+}
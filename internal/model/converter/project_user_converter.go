@@ -0,0 +1,24 @@
+package converter
+
+import (
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+)
+
+func ProjectMemberToResponse(member *entity.ProjectUser) *model.ProjectMemberResponse {
+	response := &model.ProjectMemberResponse{
+		ID:        member.ID,
+		ProjectId: member.ProjectId,
+		UserId:    member.UserId,
+		Role:      member.Role,
+		CreatedAt: member.CreatedAt,
+		UpdatedAt: member.UpdatedAt,
+	}
+
+	if member.User != nil {
+		response.Name = member.User.Name
+		response.Email = member.User.Email
+	}
+
+	return response
+}
@@ -7,9 +7,10 @@ import (
 
 func RoleToResponse(role *entity.Role) *model.RoleResponse {
 	return &model.RoleResponse{
-		ID:        role.ID,
-		Name:      role.Name,
-		CreatedAt: role.CreatedAt,
-		UpdatedAt: role.UpdatedAt,
+		ID:          role.ID,
+		Name:        role.Name,
+		MfaRequired: role.MfaRequired,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
 	}
 }
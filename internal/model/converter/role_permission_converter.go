@@ -0,0 +1,18 @@
+package converter
+
+import (
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+)
+
+func RolePermissionsToResponse(roleId string, rolePermissions []entity.RolePermission) *model.RolePermissionResponse {
+	permissions := make([]string, len(rolePermissions))
+	for i, rolePermission := range rolePermissions {
+		permissions[i] = rolePermission.Permission
+	}
+
+	return &model.RolePermissionResponse{
+		RoleId:      roleId,
+		Permissions: permissions,
+	}
+}
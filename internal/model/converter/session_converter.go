@@ -0,0 +1,19 @@
+package converter
+
+import (
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+)
+
+func SessionToResponse(session *entity.UserSession, currentSessionId string) *model.SessionResponse {
+	return &model.SessionResponse{
+		ID:          session.ID,
+		DeviceLabel: session.DeviceLabel,
+		IpAddress:   session.IpAddress,
+		UserAgent:   session.UserAgent,
+		Current:     session.ID == currentSessionId,
+		CreatedAt:   session.CreatedAt,
+		LastSeenAt:  session.LastSeenAt,
+		ExpiresAt:   session.ExpiresAt,
+	}
+}
@@ -17,9 +17,60 @@ func UserToResponse(user *entity.User) *model.UserResponse {
 	}
 }
 
-func UserToTokenResponse(user *entity.User, expiresIn int64) *model.UserResponse {
+func UserToMfaChallengeResponse(mfaToken string, mfaExpiresIn int64) *model.UserResponse {
 	return &model.UserResponse{
-		Token:     user.Token,
-		ExpiresIn: expiresIn,
+		MfaRequired:  true,
+		MfaToken:     mfaToken,
+		MfaExpiresIn: mfaExpiresIn,
+	}
+}
+
+func UserToTokenResponse(accessToken string, expiresIn int64, refreshToken string, refreshExpiresIn int64) *model.UserResponse {
+	return &model.UserResponse{
+		Token:            accessToken,
+		ExpiresIn:        expiresIn,
+		RefreshToken:     refreshToken,
+		RefreshExpiresIn: refreshExpiresIn,
+	}
+}
+
+func UserToAdminResponse(user *entity.User) *model.AdminUserResponse {
+	response := &model.AdminUserResponse{
+		ID:           user.ID,
+		Email:        user.Email,
+		Name:         user.Name,
+		RoleId:       user.RoleId,
+		DepartmentId: user.DepartementId,
+		IsActive:     user.IsActive,
+		CreatedAt:    user.CreatedAt,
+		UpdatedAt:    user.UpdatedAt,
+	}
+
+	if user.DeletedAt.Valid {
+		response.DeletedAt = &user.DeletedAt.Time
+	}
+
+	return response
+}
+
+func UserToRegisteredEvent(user *entity.User, source string) *model.UserRegisteredEvent {
+	return &model.UserRegisteredEvent{
+		ID:           user.ID,
+		Email:        user.Email,
+		Name:         user.Name,
+		RoleId:       user.RoleId,
+		DepartmentId: user.DepartementId,
+		Source:       source,
+		RegisteredAt: user.CreatedAt,
+	}
+}
+
+func UserToRoleChangedEvent(user *entity.User, previousRoleId string, changedBy string) *model.RoleChangedEvent {
+	return &model.RoleChangedEvent{
+		ID:             user.ID,
+		PreviousRoleId: previousRoleId,
+		RoleId:         user.RoleId,
+		ChangedBy:      changedBy,
+		ChangedAt:      user.UpdatedAt,
 	}
 }
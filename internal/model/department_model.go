@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+type DepartmentResponse struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+type CreateDepartmentRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type UpdateDepartmentRequest struct {
+	ID   string `json:"-" validate:"required,max=100,uuid"`
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type SearchDepartmentRequest struct {
+	Name string `json:"name" validate:"max=100"`
+	Page int    `json:"page" validate:"min=1"`
+	Size int    `json:"size" validate:"min=1,max=100"`
+}
+
+type GetDepartmentRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type DeleteDepartmentRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type MoveDepartmentUsersRequest struct {
+	ID      string   `json:"-" validate:"required,max=100,uuid"`
+	UserIds []string `json:"user_ids" validate:"required,min=1,max=100,dive,required,max=100"`
+}
@@ -1,5 +1,43 @@
 package model
 
+import "time"
+
+const (
+	// EventSpecVersion is the CloudEvents version the envelope follows
+	EventSpecVersion = "1.0"
+	// EventSource is the source of every event published by this application
+	EventSource      = "todo-app"
+	EventContentType = "application/json"
+)
+
+// Event is published to kafka inside an EventEnvelope. GetId is the id of the entity, it is the subject
+// of the envelope and the message key. A change to the json fields that breaks existing consumers needs
+// a new GetEventVersion, the eventschema test checks this against the recorded schemas.
 type Event interface {
 	GetId() string
+	GetEventType() string
+	GetEventVersion() int
+}
+
+// EventEnvelope is the metadata of an event in the spirit of CloudEvents, sent as kafka headers next to the event body
+type EventEnvelope struct {
+	SpecVersion     string
+	ID              string
+	Source          string
+	Type            string
+	Version         int
+	Subject         string
+	Time            time.Time
+	CorrelationId   string
+	DataContentType string
+}
+
+// Events lists every published event, the eventschema test records the schema of each of them
+func Events() []Event {
+	return []Event{
+		new(UserRegisteredEvent),
+		new(RoleChangedEvent),
+		new(CardMovedEvent),
+		new(CardClosedEvent),
+	}
 }
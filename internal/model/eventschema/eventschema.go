@@ -0,0 +1,155 @@
+package eventschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"todo-app/internal/model"
+)
+
+// Schema is the json shape of one type and version of event, recorded in the schemas folder
+type Schema struct {
+	Type    string  `json:"type"`
+	Version int     `json:"version"`
+	Fields  []Field `json:"fields"`
+}
+
+type Field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Describe reads the json fields of the event, nested objects are flattened with a dot
+func Describe(event model.Event) *Schema {
+	schema := &Schema{
+		Type:    event.GetEventType(),
+		Version: event.GetEventVersion(),
+	}
+	schema.Fields = describeFields(reflect.TypeOf(event), "")
+	sort.Slice(schema.Fields, func(i, j int) bool {
+		return schema.Fields[i].Name < schema.Fields[j].Name
+	})
+	return schema
+}
+
+func describeFields(structType reflect.Type, prefix string) []Field {
+	for structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+
+	var fields []Field
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+
+		name := structField.Name
+		if tag, ok := structField.Tag.Lookup("json"); ok {
+			tagName, _, _ := strings.Cut(tag, ",")
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+
+		fieldType := structField.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct && fieldType != reflect.TypeOf(time.Time{}) {
+			fields = append(fields, Field{Name: prefix + name, Type: "object"})
+			fields = append(fields, describeFields(fieldType, prefix+name+".")...)
+			continue
+		}
+		fields = append(fields, Field{Name: prefix + name, Type: jsonType(fieldType)})
+	}
+	return fields
+}
+
+// jsonType adalah tipe nilai di json, bukan tipe Go, supaya int32 ke int64 tidak dianggap perubahan
+func jsonType(fieldType reflect.Type) string {
+	if fieldType == reflect.TypeOf(time.Time{}) {
+		return "timestamp"
+	}
+
+	switch fieldType.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		elemType := fieldType.Elem()
+		for elemType.Kind() == reflect.Pointer {
+			elemType = elemType.Elem()
+		}
+		return "array<" + jsonType(elemType) + ">"
+	case reflect.Map:
+		return "object"
+	default:
+		return fieldType.Kind().String()
+	}
+}
+
+// Compare returns why the current schema breaks consumers of the recorded one.
+// A new field is compatible, a removed or renamed field or a field with another type is not.
+func Compare(recorded *Schema, current *Schema) []string {
+	currentFields := make(map[string]string, len(current.Fields))
+	for _, field := range current.Fields {
+		currentFields[field.Name] = field.Type
+	}
+
+	var problems []string
+	for _, field := range recorded.Fields {
+		fieldType, ok := currentFields[field.Name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("field %s was removed", field.Name))
+			continue
+		}
+		if fieldType != field.Type {
+			problems = append(problems, fmt.Sprintf("field %s changed from %s to %s", field.Name, field.Type, fieldType))
+		}
+	}
+	return problems
+}
+
+func FileName(eventType string, version int) string {
+	return fmt.Sprintf("%s.v%d.json", eventType, version)
+}
+
+// Load returns nil when no schema was recorded for the type and version yet
+func Load(dir string, eventType string, version int) (*Schema, error) {
+	data, err := os.ReadFile(filepath.Join(dir, FileName(eventType, version)))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	schema := new(Schema)
+	if err := json.Unmarshal(data, schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+func Save(dir string, schema *Schema) error {
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, FileName(schema.Type, schema.Version)), append(data, '\n'), 0o644)
+}
@@ -0,0 +1,78 @@
+package eventschema
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+	"todo-app/internal/model"
+)
+
+// Schema baru atau field baru yang kompatibel direkam dengan:
+//
+//	go test ./internal/model/eventschema -update
+var update = flag.Bool("update", false, "record new events and new fields of compatible events")
+
+const schemaDir = "schemas"
+
+func TestEventsMatchRecordedSchemas(t *testing.T) {
+	for _, event := range model.Events() {
+		current := Describe(event)
+		name := FileName(current.Type, current.Version)
+
+		t.Run(name, func(t *testing.T) {
+			recorded, err := Load(schemaDir, current.Type, current.Version)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			switch {
+			case recorded == nil && !*update:
+				t.Fatalf("%s has no recorded schema, run the test with -update to record it", name)
+			case recorded != nil:
+				// Schema yang tidak kompatibel tidak pernah ditimpa, versinya harus dinaikkan
+				if problems := Compare(recorded, current); len(problems) > 0 {
+					t.Fatalf("%s is not compatible, bump GetEventVersion of %s: %v",
+						name, reflect.TypeOf(event).Elem().Name(), problems)
+				}
+				if reflect.DeepEqual(recorded, current) {
+					return
+				}
+				if !*update {
+					t.Fatalf("%s has new fields, run the test with -update to record them", name)
+				}
+			}
+
+			if err := Save(schemaDir, current); err != nil {
+				t.Fatal(err)
+			}
+			t.Logf("%s recorded", name)
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	recorded := &Schema{Type: "card.moved", Version: 1, Fields: []Field{
+		{Name: "id", Type: "string"},
+		{Name: "position", Type: "integer"},
+	}}
+
+	tests := []struct {
+		name     string
+		fields   []Field
+		problems int
+	}{
+		{name: "same fields", fields: recorded.Fields},
+		{name: "new field", fields: append([]Field{{Name: "board_id", Type: "string"}}, recorded.Fields...)},
+		{name: "removed field", fields: []Field{{Name: "id", Type: "string"}}, problems: 1},
+		{name: "changed type", fields: []Field{{Name: "id", Type: "string"}, {Name: "position", Type: "string"}}, problems: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := &Schema{Type: recorded.Type, Version: recorded.Version, Fields: tt.fields}
+			if problems := Compare(recorded, current); len(problems) != tt.problems {
+				t.Fatalf("Compare returned %v, want %d problems", problems, tt.problems)
+			}
+		})
+	}
+}
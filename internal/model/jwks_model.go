@@ -0,0 +1,17 @@
+package model
+
+// JSONWebKeyResponse is a public signing key in JWK format (RFC 7517)
+type JSONWebKeyResponse struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JSONWebKeySetResponse struct {
+	Keys []JSONWebKeyResponse `json:"keys"`
+}
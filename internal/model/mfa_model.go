@@ -0,0 +1,43 @@
+package model
+
+type MfaEnrollResponse struct {
+	Secret     string `json:"secret"`
+	OtpauthUri string `json:"otpauth_uri"`
+}
+
+type MfaRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type EnrollMfaRequest struct {
+	UserId string `json:"-" validate:"required,max=100"`
+}
+
+type ConfirmMfaRequest struct {
+	UserId string `json:"-" validate:"required,max=100"`
+	Code   string `json:"code" validate:"required,numeric,len=6"`
+}
+
+type RegenerateRecoveryCodesRequest struct {
+	UserId string `json:"-" validate:"required,max=100"`
+	Code   string `json:"code" validate:"required,numeric,len=6"`
+}
+
+type DisableMfaRequest struct {
+	UserId string `json:"-" validate:"required,max=100"`
+	Code   string `json:"code" validate:"required,numeric,len=6"`
+}
+
+type LoginMfaRequest struct {
+	MfaToken     string `json:"mfa_token" validate:"required,max=500"`
+	Code         string `json:"code" validate:"required_without=RecoveryCode,omitempty,numeric,len=6"`
+	RecoveryCode string `json:"recovery_code" validate:"required_without=Code,max=20"`
+	DeviceLabel  string `json:"device_label" validate:"max=100"`
+	IpAddress    string `json:"-" validate:"max=100"`
+	UserAgent    string `json:"-" validate:"max=500"`
+}
+
+type UpdateRoleMfaRequest struct {
+	ID          string `json:"-" validate:"required,max=100,uuid"`
+	MfaRequired *bool  `json:"mfa_required" validate:"required"`
+}
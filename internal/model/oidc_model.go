@@ -0,0 +1,16 @@
+package model
+
+type OidcAuthorizeResponse struct {
+	AuthorizationUrl string `json:"authorization_url"`
+	// Client menyimpan state ini dan membandingkannya dengan state di redirect sebelum memanggil callback
+	State     string `json:"state"`
+	ExpiresIn int64  `json:"expires_in"`
+}
+
+type OidcCallbackRequest struct {
+	Code        string `json:"code" validate:"required,max=2000"`
+	State       string `json:"state" validate:"required,max=100"`
+	DeviceLabel string `json:"device_label" validate:"max=100"`
+	IpAddress   string `json:"-" validate:"max=100"`
+	UserAgent   string `json:"-" validate:"max=500"`
+}
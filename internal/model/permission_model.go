@@ -0,0 +1,63 @@
+package model
+
+// Permission names that can be granted to a role through role_permissions
+const (
+	PermissionRolesRead        = "roles:read"
+	PermissionRolesWrite       = "roles:write"
+	PermissionDepartmentsRead  = "departments:read"
+	PermissionDepartmentsWrite = "departments:write"
+	PermissionProjectsWrite    = "projects:write"
+	PermissionProjectsAdmin    = "projects:admin"
+	PermissionUsersAdmin       = "users:admin"
+	PermissionAuditRead        = "audit:read"
+)
+
+// Permissions lists every permission known by the application
+var Permissions = []string{
+	PermissionRolesRead,
+	PermissionRolesWrite,
+	PermissionDepartmentsRead,
+	PermissionDepartmentsWrite,
+	PermissionProjectsWrite,
+	PermissionProjectsAdmin,
+	PermissionUsersAdmin,
+	PermissionAuditRead,
+}
+
+// Scopes that only exist on personal access tokens. The routes behind them are guarded by
+// project membership instead of a role permission, so every user may put them on a token.
+const (
+	ScopeProfileRead  = "profile:read"
+	ScopeProjectsRead = "projects:read"
+	ScopeMembersWrite = "members:write"
+	ScopeBoardsWrite  = "boards:write"
+	ScopeCardsWrite   = "cards:write"
+)
+
+// TokenScopes lists every scope a personal access token may carry besides the permissions
+var TokenScopes = []string{
+	ScopeProfileRead,
+	ScopeProjectsRead,
+	ScopeMembersWrite,
+	ScopeBoardsWrite,
+	ScopeCardsWrite,
+}
+
+type RolePermissionResponse struct {
+	RoleId      string   `json:"role_id"`
+	Permissions []string `json:"permissions"`
+}
+
+type GetRolePermissionRequest struct {
+	RoleId string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type UpdateRolePermissionRequest struct {
+	RoleId      string   `json:"-" validate:"required,max=100,uuid"`
+	Permissions []string `json:"permissions" validate:"max=100,unique,dive,required,max=100"`
+}
+
+type CheckPermissionRequest struct {
+	RoleId      string   `validate:"required,max=100"`
+	Permissions []string `validate:"required,min=1,dive,required,max=100"`
+}
@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+type PersonalAccessTokenResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	Token      string     `json:"token,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type CreatePersonalAccessTokenRequest struct {
+	UserId    string     `json:"-" validate:"required,max=100"`
+	RoleId    string     `json:"-" validate:"max=100"`
+	Name      string     `json:"name" validate:"required,max=100"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1,unique,dive,required,max=100"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type ListPersonalAccessTokenRequest struct {
+	UserId string `json:"-" validate:"required,max=100"`
+}
+
+type RevokePersonalAccessTokenRequest struct {
+	UserId string `json:"-" validate:"required,max=100"`
+	ID     string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type VerifyPersonalAccessTokenRequest struct {
+	Token string `validate:"required,max=500"`
+}
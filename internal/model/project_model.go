@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+type ProjectResponse struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	DepartmentId string     `json:"department_id"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	DeletedAt    *time.Time `json:"deleted_at"`
+}
+
+type CreateProjectRequest struct {
+	UserId       string `json:"-" validate:"required,max=100"`
+	Name         string `json:"name" validate:"required,max=100"`
+	DepartmentId string `json:"department_id" validate:"required,max=100,uuid"`
+}
+
+type UpdateProjectRequest struct {
+	ID           string `json:"-" validate:"required,max=100,uuid"`
+	Name         string `json:"name" validate:"required,max=100"`
+	DepartmentId string `json:"department_id" validate:"required,max=100,uuid"`
+}
+
+type SearchProjectRequest struct {
+	Name         string `json:"name" validate:"max=100"`
+	DepartmentId string `json:"department_id" validate:"omitempty,max=100,uuid"`
+	Page         int    `json:"page" validate:"min=1"`
+	Size         int    `json:"size" validate:"min=1,max=100"`
+}
+
+type GetProjectRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type DeleteProjectRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
+}
@@ -0,0 +1,49 @@
+package model
+
+import "time"
+
+// Member roles inside a single project
+const (
+	ProjectRoleOwner      = "owner"
+	ProjectRoleMaintainer = "maintainer"
+	ProjectRoleViewer     = "viewer"
+)
+
+type ProjectMemberResponse struct {
+	ID        string    `json:"id"`
+	ProjectId string    `json:"project_id"`
+	UserId    string    `json:"user_id"`
+	Name      string    `json:"name,omitempty"`
+	Email     string    `json:"email,omitempty"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type AddProjectMemberRequest struct {
+	ActorId   string `json:"-" validate:"required,max=100"`
+	ProjectId string `json:"-" validate:"required,max=100,uuid"`
+	UserId    string `json:"user_id" validate:"required,max=100"`
+	Role      string `json:"role" validate:"required,oneof=owner maintainer viewer"`
+}
+
+type UpdateProjectMemberRequest struct {
+	ActorId   string `json:"-" validate:"required,max=100"`
+	ProjectId string `json:"-" validate:"required,max=100,uuid"`
+	UserId    string `json:"-" validate:"required,max=100"`
+	Role      string `json:"role" validate:"required,oneof=owner maintainer viewer"`
+}
+
+type RemoveProjectMemberRequest struct {
+	ActorId   string `json:"-" validate:"required,max=100"`
+	ProjectId string `json:"-" validate:"required,max=100,uuid"`
+	UserId    string `json:"-" validate:"required,max=100"`
+}
+
+type SearchProjectMemberRequest struct {
+	ActorId   string `json:"-" validate:"required,max=100"`
+	ProjectId string `json:"-" validate:"required,max=100,uuid"`
+	Role      string `json:"role" validate:"omitempty,oneof=owner maintainer viewer"`
+	Page      int    `json:"page" validate:"min=1"`
+	Size      int    `json:"size" validate:"min=1,max=100"`
+}
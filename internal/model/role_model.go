@@ -3,11 +3,12 @@ package model
 import "time"
 
 type RoleResponse struct {
-	ID        string     `json:"id"`
-	Name      string     `json:"name"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
-	DeletedAt *time.Time `json:"deleted_at"`
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	MfaRequired bool       `json:"mfa_required"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at"`
 }
 
 type CreateRoleRequest struct {
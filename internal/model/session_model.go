@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+type SessionResponse struct {
+	ID          string    `json:"id"`
+	DeviceLabel string    `json:"device_label,omitempty"`
+	IpAddress   string    `json:"ip_address,omitempty"`
+	UserAgent   string    `json:"user_agent,omitempty"`
+	Current     bool      `json:"current"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type ListSessionRequest struct {
+	UserId           string `json:"-" validate:"required,max=100"`
+	CurrentSessionId string `json:"-" validate:"max=100"`
+}
+
+type RevokeSessionRequest struct {
+	UserId string `json:"-" validate:"required,max=100"`
+	ID     string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type RevokeAllSessionRequest struct {
+	UserId string `json:"-" validate:"required,max=100"`
+}
@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+type AdminUserResponse struct {
+	ID           string     `json:"id"`
+	Email        string     `json:"email"`
+	Name         string     `json:"name"`
+	RoleId       string     `json:"role_id"`
+	DepartmentId string     `json:"department_id"`
+	IsActive     bool       `json:"is_active"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	DeletedAt    *time.Time `json:"deleted_at"`
+}
+
+type SearchUserRequest struct {
+	Name         string `json:"name" validate:"max=100"`
+	Email        string `json:"email" validate:"max=100"`
+	RoleId       string `json:"role_id" validate:"omitempty,max=100,uuid"`
+	DepartmentId string `json:"department_id" validate:"omitempty,max=100,uuid"`
+	IsActive     *bool  `json:"is_active"`
+	Page         int    `json:"page" validate:"min=1"`
+	Size         int    `json:"size" validate:"min=1,max=100"`
+}
+
+type AdminGetUserRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type AdminUpdateUserRequest struct {
+	ActorId      string `json:"-" validate:"required,max=100"`
+	ID           string `json:"-" validate:"required,max=100,uuid"`
+	Name         string `json:"name,omitempty" validate:"max=100"`
+	RoleId       string `json:"role_id,omitempty" validate:"omitempty,max=100,uuid"`
+	DepartmentId string `json:"department_id,omitempty" validate:"omitempty,max=100,uuid"`
+	IsActive     *bool  `json:"is_active,omitempty"`
+}
+
+type AdminDeleteUserRequest struct {
+	ActorId string `json:"-" validate:"required,max=100"`
+	ID      string `json:"-" validate:"required,max=100,uuid"`
+}
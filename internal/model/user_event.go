@@ -0,0 +1,59 @@
+package model
+
+import "time"
+
+// Tipe event sama dengan nama topic tempat event dikirim
+const (
+	EventTypeUserRegistered = "user.registered"
+	EventTypeRoleChanged    = "user.role_changed"
+)
+
+const (
+	UserRegistrationSourcePassword = "password"
+	UserRegistrationSourceOidc     = "oidc"
+)
+
+// UserRegisteredEvent is published after a new user is stored, through sign up or a first OIDC login
+type UserRegisteredEvent struct {
+	ID           string    `json:"id"`
+	Email        string    `json:"email"`
+	Name         string    `json:"name"`
+	RoleId       string    `json:"role_id"`
+	DepartmentId string    `json:"department_id"`
+	Source       string    `json:"source"`
+	RegisteredAt time.Time `json:"registered_at"`
+}
+
+func (e *UserRegisteredEvent) GetId() string {
+	return e.ID
+}
+
+func (e *UserRegisteredEvent) GetEventType() string {
+	return EventTypeUserRegistered
+}
+
+func (e *UserRegisteredEvent) GetEventVersion() int {
+	return 1
+}
+
+// RoleChangedEvent is published when a user is moved to another role.
+// ChangedBy is empty when the role came from the identity provider groups.
+type RoleChangedEvent struct {
+	ID             string    `json:"id"`
+	PreviousRoleId string    `json:"previous_role_id"`
+	RoleId         string    `json:"role_id"`
+	ChangedBy      string    `json:"changed_by,omitempty"`
+	ChangedAt      time.Time `json:"changed_at"`
+}
+
+func (e *RoleChangedEvent) GetId() string {
+	return e.ID
+}
+
+func (e *RoleChangedEvent) GetEventType() string {
+	return EventTypeRoleChanged
+}
+
+func (e *RoleChangedEvent) GetEventVersion() int {
+	return 1
+}
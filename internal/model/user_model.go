@@ -1,40 +1,79 @@
 package model
 
 type UserResponse struct {
-	ID            string `json:"id,omitempty"`
-	Email         string `json:"email,omitempty"`
-	Name          string `json:"name,omitempty"`
-	RoleId        string `json:"role_id,omitempty"`
-	DepartementId string `json:"department_id,omitempty"`
-	IsActive      bool   `json:"is_active,omitempty"`
-	Token         string `json:"token,omitempty"`
-	ExpiresIn     int64  `json:"expires_in,omitempty"`
+	ID               string `json:"id,omitempty"`
+	Email            string `json:"email,omitempty"`
+	Name             string `json:"name,omitempty"`
+	RoleId           string `json:"role_id,omitempty"`
+	DepartementId    string `json:"department_id,omitempty"`
+	IsActive         bool   `json:"is_active,omitempty"`
+	Token            string `json:"token,omitempty"`
+	ExpiresIn        int64  `json:"expires_in,omitempty"`
+	RefreshToken     string `json:"refresh_token,omitempty"`
+	RefreshExpiresIn int64  `json:"refresh_expires_in,omitempty"`
+	MfaRequired      bool   `json:"mfa_required,omitempty"`
+	MfaToken         string `json:"mfa_token,omitempty"`
+	MfaExpiresIn     int64  `json:"mfa_expires_in,omitempty"`
 }
 
 type VerifyUserRequest struct {
-	Token string `validate:"required,max=500"`
+	Token string `validate:"required,max=4096"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required,max=500"`
+}
+
+type ResendVerificationEmailRequest struct {
+	Email string `json:"email" validate:"required,email,max=100"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email,max=100"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required,max=500"`
+	Password string `json:"password" validate:"required,max=100,password"`
+}
+
+type RefreshUserRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required,max=500"`
+	IpAddress    string `json:"-" validate:"max=100"`
+	UserAgent    string `json:"-" validate:"max=500"`
 }
 
 type RegisterUserRequest struct {
-	Email    string `json:"email" validate:"required,email,max=100"`
-	Password string `json:"password" validate:"required,max=100"`
-	Name     string `json:"name" validate:"required,max=100"`
+	Email        string `json:"email" validate:"required,email,max=100"`
+	Password     string `json:"password" validate:"required,max=100,password"`
+	Name         string `json:"name" validate:"required,max=100"`
+	DepartmentId string `json:"department_id" validate:"required,max=100,uuid"`
 }
 
 type UpdateUserRequest struct {
-	ID       string `json:"-" validate:"required,max=100"`
-	Email    string `json:"email,omitempty" validate:"omitempty,email,max=100"`
-	Password string `json:"password,omitempty" validate:"max=100"`
-	Name     string `json:"name,omitempty" validate:"max=100"`
+	ID              string `json:"-" validate:"required,max=100"`
+	Email           string `json:"email,omitempty" validate:"omitempty,email,max=100"`
+	Password        string `json:"password,omitempty" validate:"max=100,password"`
+	CurrentPassword string `json:"current_password,omitempty" validate:"required_with=Password,max=100"`
+	Name            string `json:"name,omitempty" validate:"max=100"`
+	IpAddress       string `json:"-" validate:"max=100"`
 }
 
 type LoginUserRequest struct {
-	Email    string `json:"email" validate:"required,email,max=100"`
-	Password string `json:"password" validate:"required,max=100"`
+	Email       string `json:"email" validate:"required,email,max=100"`
+	Password    string `json:"password" validate:"required,max=100"`
+	DeviceLabel string `json:"device_label" validate:"max=100"`
+	IpAddress   string `json:"-" validate:"max=100"`
+	UserAgent   string `json:"-" validate:"max=500"`
 }
 
 type LogoutUserRequest struct {
-	ID string `json:"id" validate:"required,max=100"`
+	ID        string `json:"id" validate:"required,max=100"`
+	SessionId string `json:"-" validate:"required,max=100"`
+}
+
+type UnlockUserRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
 }
 
 type GetUserRequest struct {
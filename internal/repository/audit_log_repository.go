@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type AuditLogRepository struct {
+	Repository[entity.AuditLog]
+	Log *logrus.Logger
+}
+
+func NewAuditLogRepository(log *logrus.Logger) *AuditLogRepository {
+	return &AuditLogRepository{
+		Log: log,
+	}
+}
+
+func (r *AuditLogRepository) Search(db *gorm.DB, request *model.SearchAuditLogRequest) ([]entity.AuditLog, int64, error) {
+	var auditLogs []entity.AuditLog
+
+	page := request.Page
+	if page < 1 {
+		page = 1
+	}
+	size := request.Size
+	if size <= 0 {
+		size = 10
+	}
+
+	if err := db.Model(&entity.AuditLog{}).
+		Scopes(r.FilterAuditLog(request)).
+		Order("created_at DESC, id DESC").
+		Offset((page - 1) * size).
+		Limit(size).
+		Find(&auditLogs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := db.Model(&entity.AuditLog{}).Scopes(r.FilterAuditLog(request)).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return auditLogs, total, nil
+}
+
+// FindAfter returns the next entries oldest first after the given (created_at, id) cursor,
+// so the CSV export can walk the whole result without OFFSET or loading it at once
+func (r *AuditLogRepository) FindAfter(db *gorm.DB, request *model.SearchAuditLogRequest, cursor *entity.AuditLog, limit int) ([]entity.AuditLog, error) {
+	var auditLogs []entity.AuditLog
+
+	query := db.Model(&entity.AuditLog{}).Scopes(r.FilterAuditLog(request))
+	if cursor != nil {
+		query = query.Where("(created_at, id) > (?, ?)", cursor.CreatedAt, cursor.ID)
+	}
+
+	err := query.Order("created_at ASC, id ASC").Limit(limit).Find(&auditLogs).Error
+	return auditLogs, err
+}
+
+func (r *AuditLogRepository) FilterAuditLog(request *model.SearchAuditLogRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if actorId := request.ActorId; actorId != "" {
+			tx = tx.Where("actor_id = ?", actorId)
+		}
+		if action := request.Action; action != "" {
+			tx = tx.Where("action = ?", action)
+		}
+		if entityType := request.EntityType; entityType != "" {
+			tx = tx.Where("entity_type = ?", entityType)
+		}
+		if entityId := request.EntityId; entityId != "" {
+			tx = tx.Where("entity_id = ?", entityId)
+		}
+		if requestId := request.RequestId; requestId != "" {
+			tx = tx.Where("request_id = ?", requestId)
+		}
+		if from := request.From; from != nil {
+			tx = tx.Where("created_at >= ?", *from)
+		}
+		if to := request.To; to != nil {
+			tx = tx.Where("created_at < ?", *to)
+		}
+		return tx
+	}
+}
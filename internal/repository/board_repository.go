@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type BoardRepository struct {
+	Repository[entity.Board]
+	Log *logrus.Logger
+}
+
+func NewBoardRepository(log *logrus.Logger) *BoardRepository {
+	return &BoardRepository{
+		Log: log,
+	}
+}
+
+func (r *BoardRepository) FindByIdAndProjectId(db *gorm.DB, board *entity.Board, id string, projectId string) error {
+	return db.Where("id = ? AND project_id = ?", id, projectId).First(board).Error
+}
+
+func (r *BoardRepository) Search(db *gorm.DB, request *model.SearchBoardRequest) ([]entity.Board, int64, error) {
+	var boards []entity.Board
+
+	page := request.Page
+	if page < 1 {
+		page = 1
+	}
+	size := request.Size
+	if size <= 0 {
+		size = 10
+	}
+
+	if err := db.Model(&entity.Board{}).
+		Scopes(r.FilterBoard(request)).
+		Order("created_at ASC").
+		Offset((page - 1) * size).
+		Limit(size).
+		Find(&boards).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := db.Model(&entity.Board{}).Scopes(r.FilterBoard(request)).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return boards, total, nil
+}
+
+func (r *BoardRepository) FilterBoard(request *model.SearchBoardRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		tx = tx.Where("project_id = ?", request.ProjectId)
+		if name := request.Name; name != "" {
+			tx = tx.Where("name LIKE ?", "%"+name+"%")
+		}
+		return tx
+	}
+}
+
+func (r *BoardRepository) SoftDelete(db *gorm.DB, board *entity.Board) error {
+	return db.Delete(board).Error
+}
+
+func (r *BoardRepository) Restore(db *gorm.DB, id string, projectId string) error {
+	return db.Unscoped().
+		Model(&entity.Board{}).
+		Where("id = ? AND project_id = ?", id, projectId).
+		Update("deleted_at", nil).Error
+}
+
+func (r *BoardRepository) SearchTrashed(db *gorm.DB, request *model.SearchBoardRequest) ([]entity.Board, int64, error) {
+	var boards []entity.Board
+
+	page := request.Page
+	if page < 1 {
+		page = 1
+	}
+	size := request.Size
+	if size <= 0 {
+		size = 10
+	}
+
+	if err := db.Unscoped().Model(&entity.Board{}).
+		Where("deleted_at IS NOT NULL").
+		Scopes(r.FilterBoard(request)).
+		Order("deleted_at DESC").
+		Offset((page - 1) * size).
+		Limit(size).
+		Find(&boards).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := db.Unscoped().Model(&entity.Board{}).
+		Where("deleted_at IS NOT NULL").
+		Scopes(r.FilterBoard(request)).
+		Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return boards, total, nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type CardRepository struct {
+	Repository[entity.Card]
+	Log *logrus.Logger
+}
+
+func NewCardRepository(log *logrus.Logger) *CardRepository {
+	return &CardRepository{
+		Log: log,
+	}
+}
+
+// FindByIdAndProjectId loads a card only when its board is still active inside the given project
+func (r *CardRepository) FindByIdAndProjectId(db *gorm.DB, card *entity.Card, id string, projectId string) error {
+	return db.Joins("JOIN boards ON boards.id = cards.board_id AND boards.deleted_at IS NULL").
+		Where("cards.id = ? AND boards.project_id = ?", id, projectId).
+		First(card).Error
+}
+
+func (r *CardRepository) Search(db *gorm.DB, request *model.SearchCardRequest) ([]entity.Card, int64, error) {
+	var cards []entity.Card
+
+	page := request.Page
+	if page < 1 {
+		page = 1
+	}
+	size := request.Size
+	if size <= 0 {
+		size = 10
+	}
+
+	if err := db.Model(&entity.Card{}).
+		Scopes(r.FilterCard(request)).
+		Order("created_at ASC").
+		Offset((page - 1) * size).
+		Limit(size).
+		Find(&cards).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := db.Model(&entity.Card{}).Scopes(r.FilterCard(request)).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return cards, total, nil
+}
+
+func (r *CardRepository) FilterCard(request *model.SearchCardRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		tx = tx.Where("board_id = ?", request.BoardId)
+		if name := request.Name; name != "" {
+			tx = tx.Where("name LIKE ?", "%"+name+"%")
+		}
+		return tx
+	}
+}
+
+func (r *CardRepository) SoftDelete(db *gorm.DB, card *entity.Card) error {
+	return db.Delete(card).Error
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type DepartmentRepository struct {
+	Repository[entity.Department]
+	Log *logrus.Logger
+}
+
+func NewDepartmentRepository(log *logrus.Logger) *DepartmentRepository {
+	return &DepartmentRepository{
+		Log: log,
+	}
+}
+
+func (r *DepartmentRepository) FindById(db *gorm.DB, department *entity.Department, id string) error {
+	return db.Where("id = ?", id).First(department).Error
+}
+
+func (r *DepartmentRepository) Search(db *gorm.DB, request *model.SearchDepartmentRequest) ([]entity.Department, int64, error) {
+	var departments []entity.Department
+
+	page := request.Page
+	if page < 1 {
+		page = 1
+	}
+	size := request.Size
+	if size <= 0 {
+		size = 10
+	}
+
+	if err := db.Model(&entity.Department{}).
+		Scopes(r.FilterDepartment(request)).
+		Order("name ASC").
+		Offset((page - 1) * size).
+		Limit(size).
+		Find(&departments).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := db.Model(&entity.Department{}).Scopes(r.FilterDepartment(request)).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return departments, total, nil
+}
+
+func (r *DepartmentRepository) FilterDepartment(request *model.SearchDepartmentRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if name := request.Name; name != "" {
+			tx = tx.Where("name LIKE ?", "%"+name+"%")
+		}
+		return tx
+	}
+}
+
+func (r *DepartmentRepository) SoftDelete(db *gorm.DB, department *entity.Department) error {
+	return db.Delete(department).Error
+}
+
+func (r *DepartmentRepository) Restore(db *gorm.DB, id string) error {
+	return db.Unscoped().
+		Model(&entity.Department{}).
+		Where("id = ?", id).
+		Update("deleted_at", nil).Error
+}
+
+func (r *DepartmentRepository) ForceDelete(db *gorm.DB, id string) error {
+	return db.Unscoped().Where("id = ?", id).Delete(&entity.Department{}).Error
+}
+
+func (r *DepartmentRepository) SearchTrashed(db *gorm.DB, request *model.SearchDepartmentRequest) ([]entity.Department, int64, error) {
+	var departments []entity.Department
+
+	page := request.Page
+	if page < 1 {
+		page = 1
+	}
+	size := request.Size
+	if size <= 0 {
+		size = 10
+	}
+
+	if err := db.Unscoped().Model(&entity.Department{}).
+		Where("deleted_at IS NOT NULL").
+		Scopes(r.FilterDepartment(request)).
+		Order("deleted_at DESC").
+		Offset((page - 1) * size).
+		Limit(size).
+		Find(&departments).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := db.Unscoped().Model(&entity.Department{}).
+		Where("deleted_at IS NOT NULL").
+		Scopes(r.FilterDepartment(request)).
+		Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return departments, total, nil
+}
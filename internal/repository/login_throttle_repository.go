@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"time"
+	"todo-app/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LoginThrottleRepository struct {
+	Repository[entity.LoginThrottle]
+	Log *logrus.Logger
+}
+
+func NewLoginThrottleRepository(log *logrus.Logger) *LoginThrottleRepository {
+	return &LoginThrottleRepository{
+		Log: log,
+	}
+}
+
+// Reserve atomically holds the key until holdUntil, but only when it is neither locked nor in backoff at now.
+// It returns false without touching the row when the key is still blocked
+func (r *LoginThrottleRepository) Reserve(db *gorm.DB, throttle *entity.LoginThrottle, key string, now time.Time, holdUntil time.Time) (bool, error) {
+	throttle.Key = key
+	throttle.NextAttemptAt = &holdUntil
+	result := db.Clauses(
+		clause.OnConflict{
+			Columns:   []clause.Column{{Name: "throttle_key"}},
+			DoUpdates: clause.Assignments(map[string]any{"next_attempt_at": holdUntil}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				gorm.Expr("(login_throttles.next_attempt_at IS NULL OR login_throttles.next_attempt_at <= ?)", now),
+				gorm.Expr("(login_throttles.locked_until IS NULL OR login_throttles.locked_until <= ?)", now),
+			}},
+		},
+		clause.Returning{},
+	).Create(throttle)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// RecordFailure writes the counter together with its backoff or lockout in a single update
+func (r *LoginThrottleRepository) RecordFailure(db *gorm.DB, throttle *entity.LoginThrottle) error {
+	return db.Model(new(entity.LoginThrottle)).Where("throttle_key = ?", throttle.Key).Updates(map[string]any{
+		"failed_count":    throttle.FailedCount,
+		"last_failed_at":  throttle.LastFailedAt,
+		"next_attempt_at": throttle.NextAttemptAt,
+		"locked_until":    throttle.LockedUntil,
+	}).Error
+}
+
+// Release lifts the hold placed by Reserve, the backoff it replaced had already passed
+func (r *LoginThrottleRepository) Release(db *gorm.DB, keys []string) error {
+	return db.Model(new(entity.LoginThrottle)).Where("throttle_key IN ?", keys).Update("next_attempt_at", nil).Error
+}
+
+func (r *LoginThrottleRepository) DeleteByKey(db *gorm.DB, key string) error {
+	return db.Where("throttle_key = ?", key).Delete(&entity.LoginThrottle{}).Error
+}
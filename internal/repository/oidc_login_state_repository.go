@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"time"
+	"todo-app/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OidcLoginStateRepository struct {
+	Repository[entity.OidcLoginState]
+	Log *logrus.Logger
+}
+
+func NewOidcLoginStateRepository(log *logrus.Logger) *OidcLoginStateRepository {
+	return &OidcLoginStateRepository{
+		Log: log,
+	}
+}
+
+// FindByStateHash loads and locks the state row so one authorization response can only be redeemed once
+func (r *OidcLoginStateRepository) FindByStateHash(db *gorm.DB, state *entity.OidcLoginState, stateHash string) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("state_hash = ?", stateHash).
+		Take(state).Error
+}
+
+// DeleteExpired removes login states that were never completed
+func (r *OidcLoginStateRepository) DeleteExpired(db *gorm.DB, now time.Time) error {
+	return db.Where("expires_at < ?", now).Delete(&entity.OidcLoginState{}).Error
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"time"
+	"todo-app/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// outboxRelayLockKey adalah key pg advisory lock, hanya satu relay yang boleh mengirim supaya urutan event terjaga
+const outboxRelayLockKey = 20251022
+
+type OutboxEventRepository struct {
+	Repository[entity.OutboxEvent]
+	Log *logrus.Logger
+}
+
+func NewOutboxEventRepository(log *logrus.Logger) *OutboxEventRepository {
+	return &OutboxEventRepository{
+		Log: log,
+	}
+}
+
+// TryLockRelay takes the relay lock until the transaction ends, false when another worker holds it
+func (r *OutboxEventRepository) TryLockRelay(tx *gorm.DB) (bool, error) {
+	var locked bool
+	err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", outboxRelayLockKey).Scan(&locked).Error
+	return locked, err
+}
+
+func (r *OutboxEventRepository) FindPending(db *gorm.DB, limit int) ([]entity.OutboxEvent, error) {
+	var outboxEvents []entity.OutboxEvent
+	if err := db.Where("published_at IS NULL").
+		Order("id ASC").
+		Limit(limit).
+		Find(&outboxEvents).Error; err != nil {
+		return nil, err
+	}
+	return outboxEvents, nil
+}
+
+func (r *OutboxEventRepository) MarkPublished(db *gorm.DB, ids []int64, now time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return db.Model(&entity.OutboxEvent{}).
+		Where("id IN ?", ids).
+		Updates(map[string]any{
+			"published_at": now,
+			"attempts":     gorm.Expr("attempts + 1"),
+			"last_error":   nil,
+		}).Error
+}
+
+func (r *OutboxEventRepository) MarkFailed(db *gorm.DB, id int64, lastError string) error {
+	return db.Model(&entity.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"attempts":   gorm.Expr("attempts + 1"),
+			"last_error": lastError,
+		}).Error
+}
+
+// DeletePublishedBefore removes delivered events older than the retention, pending events are never removed
+func (r *OutboxEventRepository) DeletePublishedBefore(db *gorm.DB, before time.Time) (int64, error) {
+	result := db.Where("published_at IS NOT NULL AND published_at < ?", before).Delete(&entity.OutboxEvent{})
+	return result.RowsAffected, result.Error
+}
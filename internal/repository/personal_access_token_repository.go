@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"time"
+	"todo-app/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PersonalAccessTokenRepository struct {
+	Repository[entity.PersonalAccessToken]
+	Log *logrus.Logger
+}
+
+func NewPersonalAccessTokenRepository(log *logrus.Logger) *PersonalAccessTokenRepository {
+	return &PersonalAccessTokenRepository{
+		Log: log,
+	}
+}
+
+func (r *PersonalAccessTokenRepository) FindByTokenHash(db *gorm.DB, token *entity.PersonalAccessToken, tokenHash string) error {
+	return db.Where("token_hash = ?", tokenHash).Take(token).Error
+}
+
+func (r *PersonalAccessTokenRepository) FindByIdAndUserId(db *gorm.DB, token *entity.PersonalAccessToken, id string, userId string) error {
+	return db.Where("id = ? AND user_id = ?", id, userId).Take(token).Error
+}
+
+func (r *PersonalAccessTokenRepository) FindActiveByUserId(db *gorm.DB, userId string, now time.Time) ([]entity.PersonalAccessToken, error) {
+	var tokens []entity.PersonalAccessToken
+	err := db.Where("user_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", userId, now).
+		Order("created_at DESC").
+		Find(&tokens).Error
+	return tokens, err
+}
+
+// TouchLastUsed only writes when the stored value is older than staleBefore, so busy scripts do not update the row on every request
+func (r *PersonalAccessTokenRepository) TouchLastUsed(db *gorm.DB, id string, now time.Time, staleBefore time.Time) error {
+	return db.Model(&entity.PersonalAccessToken{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, staleBefore).
+		Update("last_used_at", now).Error
+}
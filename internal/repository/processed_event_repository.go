@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"time"
+	"todo-app/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProcessedEventRepository struct {
+	Repository[entity.ProcessedEvent]
+	Log *logrus.Logger
+}
+
+func NewProcessedEventRepository(log *logrus.Logger) *ProcessedEventRepository {
+	return &ProcessedEventRepository{
+		Log: log,
+	}
+}
+
+// Claim stores the event for the consumer and returns false when it was already stored.
+// A second delivery running at the same time waits on the row lock until the first transaction ends.
+func (r *ProcessedEventRepository) Claim(db *gorm.DB, consumer string, eventId string) (bool, error) {
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.ProcessedEvent{
+		Consumer: consumer,
+		EventId:  eventId,
+	})
+	return result.RowsAffected > 0, result.Error
+}
+
+// DeleteProcessedBefore removes the records of events processed before the retention, a redelivery of such
+// an old event is no longer expected
+func (r *ProcessedEventRepository) DeleteProcessedBefore(db *gorm.DB, before time.Time) (int64, error) {
+	result := db.Where("processed_at < ?", before).Delete(&entity.ProcessedEvent{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ProjectRepository struct {
+	Repository[entity.Project]
+	Log *logrus.Logger
+}
+
+func NewProjectRepository(log *logrus.Logger) *ProjectRepository {
+	return &ProjectRepository{
+		Log: log,
+	}
+}
+
+func (r *ProjectRepository) FindById(db *gorm.DB, project *entity.Project, id string) error {
+	return db.Where("id = ?", id).First(project).Error
+}
+
+func (r *ProjectRepository) Search(db *gorm.DB, request *model.SearchProjectRequest) ([]entity.Project, int64, error) {
+	var projects []entity.Project
+
+	page := request.Page
+	if page < 1 {
+		page = 1
+	}
+	size := request.Size
+	if size <= 0 {
+		size = 10
+	}
+
+	if err := db.Model(&entity.Project{}).
+		Scopes(r.FilterProject(request)).
+		Order("name ASC").
+		Offset((page - 1) * size).
+		Limit(size).
+		Find(&projects).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := db.Model(&entity.Project{}).Scopes(r.FilterProject(request)).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return projects, total, nil
+}
+
+func (r *ProjectRepository) FilterProject(request *model.SearchProjectRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if name := request.Name; name != "" {
+			tx = tx.Where("name LIKE ?", "%"+name+"%")
+		}
+		if departmentId := request.DepartmentId; departmentId != "" {
+			tx = tx.Where("department_id = ?", departmentId)
+		}
+		return tx
+	}
+}
+
+func (r *ProjectRepository) SoftDelete(db *gorm.DB, project *entity.Project) error {
+	return db.Delete(project).Error
+}
+
+func (r *ProjectRepository) Restore(db *gorm.DB, id string) error {
+	return db.Unscoped().
+		Model(&entity.Project{}).
+		Where("id = ?", id).
+		Update("deleted_at", nil).Error
+}
+
+func (r *ProjectRepository) ForceDelete(db *gorm.DB, id string) error {
+	return db.Unscoped().Where("id = ?", id).Delete(&entity.Project{}).Error
+}
+
+func (r *ProjectRepository) SearchTrashed(db *gorm.DB, request *model.SearchProjectRequest) ([]entity.Project, int64, error) {
+	var projects []entity.Project
+
+	page := request.Page
+	if page < 1 {
+		page = 1
+	}
+	size := request.Size
+	if size <= 0 {
+		size = 10
+	}
+
+	if err := db.Unscoped().Model(&entity.Project{}).
+		Where("deleted_at IS NOT NULL").
+		Scopes(r.FilterProject(request)).
+		Order("deleted_at DESC").
+		Offset((page - 1) * size).
+		Limit(size).
+		Find(&projects).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := db.Unscoped().Model(&entity.Project{}).
+		Where("deleted_at IS NOT NULL").
+		Scopes(r.FilterProject(request)).
+		Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return projects, total, nil
+}
+
+func (r *ProjectRepository) CountByDepartmentId(db *gorm.DB, departmentId string) (int64, error) {
+	var total int64
+	err := db.Model(&entity.Project{}).Where("department_id = ?", departmentId).Count(&total).Error
+	return total, err
+}
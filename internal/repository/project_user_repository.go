@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProjectUserRepository struct {
+	Repository[entity.ProjectUser]
+	Log *logrus.Logger
+}
+
+func NewProjectUserRepository(log *logrus.Logger) *ProjectUserRepository {
+	return &ProjectUserRepository{
+		Log: log,
+	}
+}
+
+// FindMember loads the active membership of a user in a project that has not been deleted
+func (r *ProjectUserRepository) FindMember(db *gorm.DB, member *entity.ProjectUser, projectId string, userId string) error {
+	return db.Joins("JOIN projects ON projects.id = project_users.project_id AND projects.deleted_at IS NULL").
+		Where("project_users.project_id = ? AND project_users.user_id = ?", projectId, userId).
+		First(member).Error
+}
+
+// IsMember reports whether the user belongs to a project that has not been deleted
+func (r *ProjectUserRepository) IsMember(db *gorm.DB, projectId string, userId string) (bool, error) {
+	var total int64
+	err := db.Model(&entity.ProjectUser{}).
+		Joins("JOIN projects ON projects.id = project_users.project_id AND projects.deleted_at IS NULL").
+		Where("project_users.project_id = ? AND project_users.user_id = ?", projectId, userId).
+		Count(&total).Error
+	return total > 0, err
+}
+
+// LockByRole locks the members with the role until the transaction ends and returns their user ids.
+// Two requests demoting or removing different owners at the same time then cannot both see another owner left.
+// Postgres does not allow FOR UPDATE with COUNT, so the rows are selected and counted by the caller.
+func (r *ProjectUserRepository) LockByRole(db *gorm.DB, projectId string, role string) ([]string, error) {
+	var userIds []string
+	err := db.Model(&entity.ProjectUser{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("project_id = ? AND role = ?", projectId, role).
+		Order("user_id ASC").
+		Pluck("user_id", &userIds).Error
+	return userIds, err
+}
+
+// CountSoleOwnerProjects counts projects where the user is the only owner left
+func (r *ProjectUserRepository) CountSoleOwnerProjects(db *gorm.DB, userId string) (int64, error) {
+	var total int64
+	err := db.Model(&entity.ProjectUser{}).
+		Where("project_users.user_id = ? AND project_users.role = ?", userId, model.ProjectRoleOwner).
+		Where("NOT EXISTS (?)", db.Session(&gorm.Session{NewDB: true}).
+			Table("project_users AS other").
+			Select("1").
+			Where("other.project_id = project_users.project_id AND other.role = ? AND other.user_id <> project_users.user_id AND other.deleted_at IS NULL", model.ProjectRoleOwner)).
+		Count(&total).Error
+	return total, err
+}
+
+func (r *ProjectUserRepository) Search(db *gorm.DB, request *model.SearchProjectMemberRequest) ([]entity.ProjectUser, int64, error) {
+	var members []entity.ProjectUser
+
+	page := request.Page
+	if page < 1 {
+		page = 1
+	}
+	size := request.Size
+	if size <= 0 {
+		size = 10
+	}
+
+	if err := db.Model(&entity.ProjectUser{}).
+		Preload("User").
+		Scopes(r.FilterMember(request)).
+		Order("created_at ASC").
+		Offset((page - 1) * size).
+		Limit(size).
+		Find(&members).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := db.Model(&entity.ProjectUser{}).Scopes(r.FilterMember(request)).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return members, total, nil
+}
+
+func (r *ProjectUserRepository) FilterMember(request *model.SearchProjectMemberRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		tx = tx.Where("project_id = ?", request.ProjectId)
+		if role := request.Role; role != "" {
+			tx = tx.Where("role = ?", role)
+		}
+		return tx
+	}
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"time"
+	"todo-app/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RefreshTokenRepository struct {
+	Repository[entity.RefreshToken]
+	Log *logrus.Logger
+}
+
+func NewRefreshTokenRepository(log *logrus.Logger) *RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		Log: log,
+	}
+}
+
+// FindByTokenHash loads and locks the token row so two concurrent refreshes cannot rotate it twice
+func (r *RefreshTokenRepository) FindByTokenHash(db *gorm.DB, token *entity.RefreshToken, tokenHash string) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ?", tokenHash).
+		Take(token).Error
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(db *gorm.DB, familyId string, now time.Time) error {
+	return db.Model(&entity.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyId).
+		Update("revoked_at", now).Error
+}
+
+func (r *RefreshTokenRepository) RevokeByUserId(db *gorm.DB, userId string, now time.Time) error {
+	return db.Model(&entity.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userId).
+		Update("revoked_at", now).Error
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"todo-app/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type RolePermissionRepository struct {
+	Repository[entity.RolePermission]
+	Log *logrus.Logger
+}
+
+func NewRolePermissionRepository(log *logrus.Logger) *RolePermissionRepository {
+	return &RolePermissionRepository{
+		Log: log,
+	}
+}
+
+func (r *RolePermissionRepository) FindByRoleId(db *gorm.DB, roleId string) ([]entity.RolePermission, error) {
+	var rolePermissions []entity.RolePermission
+	err := db.Where("role_id = ?", roleId).Order("permission ASC").Find(&rolePermissions).Error
+	return rolePermissions, err
+}
+
+// CountGranted counts how many of the given permissions are granted to the role
+func (r *RolePermissionRepository) CountGranted(db *gorm.DB, roleId string, permissions []string) (int64, error) {
+	var total int64
+	err := db.Model(&entity.RolePermission{}).
+		Where("role_id = ? AND permission IN ?", roleId, permissions).
+		Count(&total).Error
+	return total, err
+}
+
+func (r *RolePermissionRepository) DeleteByRoleId(db *gorm.DB, roleId string) error {
+	return db.Where("role_id = ?", roleId).Delete(&entity.RolePermission{}).Error
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"time"
+	"todo-app/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type TokenRevocationRepository struct {
+	Repository[entity.TokenRevocation]
+	Log *logrus.Logger
+}
+
+func NewTokenRevocationRepository(log *logrus.Logger) *TokenRevocationRepository {
+	return &TokenRevocationRepository{
+		Log: log,
+	}
+}
+
+// IsRevoked reports whether the token itself or its session was revoked,
+// or the user revoked every token issued before issuedAt.
+// The iat claim only has seconds, so a user-wide revocation stores revoked_at truncated to seconds and only
+// tokens of an earlier second are revoked. A token issued right after the revocation, in the same second, stays valid.
+func (r *TokenRevocationRepository) IsRevoked(db *gorm.DB, userId string, sessionId string, tokenId string, issuedAt time.Time) (bool, error) {
+	var total int64
+	err := db.Model(&entity.TokenRevocation{}).
+		Where("token_id = ?", tokenId).
+		Or("session_id = ?", sessionId).
+		Or("user_id = ? AND token_id IS NULL AND session_id IS NULL AND revoked_at > ?", userId, issuedAt).
+		Count(&total).Error
+	return total > 0, err
+}
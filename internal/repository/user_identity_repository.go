@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"todo-app/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type UserIdentityRepository struct {
+	Repository[entity.UserIdentity]
+	Log *logrus.Logger
+}
+
+func NewUserIdentityRepository(log *logrus.Logger) *UserIdentityRepository {
+	return &UserIdentityRepository{
+		Log: log,
+	}
+}
+
+func (r *UserIdentityRepository) FindByIssuerAndSubject(db *gorm.DB, identity *entity.UserIdentity, issuer string, subject string) error {
+	return db.Where("issuer = ? AND subject = ?", issuer, subject).Take(identity).Error
+}
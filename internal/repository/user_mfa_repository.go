@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"todo-app/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UserMfaRepository struct {
+	Repository[entity.UserMfa]
+	Log *logrus.Logger
+}
+
+func NewUserMfaRepository(log *logrus.Logger) *UserMfaRepository {
+	return &UserMfaRepository{
+		Log: log,
+	}
+}
+
+// FindByUserId locks the row so last_used_step cannot be raced by two logins using the same code
+func (r *UserMfaRepository) FindByUserId(db *gorm.DB, mfa *entity.UserMfa, userId string) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ?", userId).
+		Take(mfa).Error
+}
+
+func (r *UserMfaRepository) IsConfirmed(db *gorm.DB, userId string) (bool, error) {
+	var total int64
+	err := db.Model(&entity.UserMfa{}).
+		Where("user_id = ? AND confirmed_at IS NOT NULL", userId).
+		Count(&total).Error
+	return total > 0, err
+}
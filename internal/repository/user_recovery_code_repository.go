@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"todo-app/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UserRecoveryCodeRepository struct {
+	Repository[entity.UserRecoveryCode]
+	Log *logrus.Logger
+}
+
+func NewUserRecoveryCodeRepository(log *logrus.Logger) *UserRecoveryCodeRepository {
+	return &UserRecoveryCodeRepository{
+		Log: log,
+	}
+}
+
+func (r *UserRecoveryCodeRepository) FindUnusedByHash(db *gorm.DB, code *entity.UserRecoveryCode, userId string, codeHash string) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userId, codeHash).
+		Take(code).Error
+}
+
+func (r *UserRecoveryCodeRepository) DeleteByUserId(db *gorm.DB, userId string) error {
+	return db.Where("user_id = ?", userId).Delete(&entity.UserRecoveryCode{}).Error
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"todo-app/internal/entity"
+	"todo-app/internal/model"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
@@ -18,6 +19,117 @@ func NewUserRepository(log *logrus.Logger) *UserRepository {
 	}
 }
 
-func (r *UserRepository) FindByToken(db *gorm.DB, user *entity.User, token string) error {
-	return db.Where("token = ?", token).First(user).Error
+// CountByDepartmentId counts users that are not deleted, pass unscoped db to include deleted users
+func (r *UserRepository) CountByDepartmentId(db *gorm.DB, departmentId string) (int64, error) {
+	var total int64
+	err := db.Model(&entity.User{}).Where("department_id = ?", departmentId).Count(&total).Error
+	return total, err
+}
+
+func (r *UserRepository) FindByIds(db *gorm.DB, ids []string) ([]entity.User, error) {
+	var users []entity.User
+	err := db.Where("id IN ?", ids).Find(&users).Error
+	return users, err
+}
+
+func (r *UserRepository) UpdateDepartment(db *gorm.DB, ids []string, departmentId string) error {
+	return db.Model(&entity.User{}).Where("id IN ?", ids).Update("department_id", departmentId).Error
+}
+
+func (r *UserRepository) Search(db *gorm.DB, request *model.SearchUserRequest) ([]entity.User, int64, error) {
+	var users []entity.User
+
+	page := request.Page
+	if page < 1 {
+		page = 1
+	}
+	size := request.Size
+	if size <= 0 {
+		size = 10
+	}
+
+	if err := db.Model(&entity.User{}).
+		Scopes(r.FilterUser(request)).
+		Order("name ASC").
+		Offset((page - 1) * size).
+		Limit(size).
+		Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := db.Model(&entity.User{}).Scopes(r.FilterUser(request)).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+func (r *UserRepository) FilterUser(request *model.SearchUserRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if name := request.Name; name != "" {
+			tx = tx.Where("name ILIKE ?", "%"+name+"%")
+		}
+		if email := request.Email; email != "" {
+			tx = tx.Where("email ILIKE ?", "%"+email+"%")
+		}
+		if roleId := request.RoleId; roleId != "" {
+			tx = tx.Where("role_id = ?", roleId)
+		}
+		if departmentId := request.DepartmentId; departmentId != "" {
+			tx = tx.Where("department_id = ?", departmentId)
+		}
+		if request.IsActive != nil {
+			tx = tx.Where("is_active = ?", *request.IsActive)
+		}
+		return tx
+	}
+}
+
+func (r *UserRepository) SoftDelete(db *gorm.DB, user *entity.User) error {
+	return db.Delete(user).Error
+}
+
+func (r *UserRepository) Restore(db *gorm.DB, id string) error {
+	return db.Unscoped().
+		Model(&entity.User{}).
+		Where("id = ?", id).
+		Update("deleted_at", nil).Error
+}
+
+func (r *UserRepository) ForceDelete(db *gorm.DB, id string) error {
+	return db.Unscoped().Where("id = ?", id).Delete(&entity.User{}).Error
+}
+
+func (r *UserRepository) SearchTrashed(db *gorm.DB, request *model.SearchUserRequest) ([]entity.User, int64, error) {
+	var users []entity.User
+
+	page := request.Page
+	if page < 1 {
+		page = 1
+	}
+	size := request.Size
+	if size <= 0 {
+		size = 10
+	}
+
+	if err := db.Unscoped().Model(&entity.User{}).
+		Where("deleted_at IS NOT NULL").
+		Scopes(r.FilterUser(request)).
+		Order("name ASC").
+		Offset((page - 1) * size).
+		Limit(size).
+		Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := db.Unscoped().Model(&entity.User{}).
+		Where("deleted_at IS NOT NULL").
+		Scopes(r.FilterUser(request)).
+		Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
 }
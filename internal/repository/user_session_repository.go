@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"time"
+	"todo-app/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type UserSessionRepository struct {
+	Repository[entity.UserSession]
+	Log *logrus.Logger
+}
+
+func NewUserSessionRepository(log *logrus.Logger) *UserSessionRepository {
+	return &UserSessionRepository{
+		Log: log,
+	}
+}
+
+func (r *UserSessionRepository) FindActiveById(db *gorm.DB, session *entity.UserSession, id string, userId string, now time.Time) error {
+	return db.Where("id = ? AND user_id = ? AND revoked_at IS NULL AND expires_at > ?", id, userId, now).
+		Take(session).Error
+}
+
+func (r *UserSessionRepository) FindActiveByUserId(db *gorm.DB, userId string, now time.Time) ([]entity.UserSession, error) {
+	var sessions []entity.UserSession
+	err := db.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userId, now).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"time"
+	"todo-app/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UserTokenRepository struct {
+	Repository[entity.UserToken]
+	Log *logrus.Logger
+}
+
+func NewUserTokenRepository(log *logrus.Logger) *UserTokenRepository {
+	return &UserTokenRepository{
+		Log: log,
+	}
+}
+
+// FindByTokenHash loads and locks the token row so it can only be consumed once
+func (r *UserTokenRepository) FindByTokenHash(db *gorm.DB, token *entity.UserToken, tokenHash string, purpose string) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ? AND purpose = ?", tokenHash, purpose).
+		Take(token).Error
+}
+
+// InvalidateByUserId marks every unused token of the given purpose as used, so only the newest one works
+func (r *UserTokenRepository) InvalidateByUserId(db *gorm.DB, userId string, purpose string, now time.Time) error {
+	return db.Model(&entity.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userId, purpose).
+		Update("used_at", now).Error
+}
+
+// MarkUsed consumes the token only when no other request used it first, false means it was already used
+func (r *UserTokenRepository) MarkUsed(db *gorm.DB, token *entity.UserToken, now time.Time) (bool, error) {
+	result := db.Model(&entity.UserToken{}).
+		Where("id = ? AND used_at IS NULL", token.ID).
+		Update("used_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	token.UsedAt = &now
+	return result.RowsAffected == 1, nil
+}
@@ -27,10 +27,10 @@ func SeedProjectUsers(db *sql.DB) error {
 	now := time.Now()
 
 	_, err := db.Exec(`
-		INSERT INTO project_users (id, project_id, user_id, created_at, updated_at)
+		INSERT INTO project_users (id, project_id, user_id, role, created_at, updated_at)
 		VALUES
-		($1, $2, $3, $4, $5),
-		($6, $7, $8, $9, $10)
+		($1, $2, $3, 'owner', $4, $5),
+		($6, $7, $8, 'owner', $9, $10)
 	`,
 		uuid.NewString(), projKanbanID, adminID, now, now,
 		uuid.NewString(), projHrID, adminID, now, now,
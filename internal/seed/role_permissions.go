@@ -0,0 +1,37 @@
+package seed
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"todo-app/internal/model"
+
+	"github.com/google/uuid"
+)
+
+func SeedRolePermissions(db *sql.DB) error {
+	var adminRoleID string
+
+	if err := db.QueryRow("SELECT id FROM roles WHERE name = $1 LIMIT 1", "Admin").Scan(&adminRoleID); err != nil {
+		return fmt.Errorf("role Admin -> roles.name not found: %v", err)
+	}
+
+	now := time.Now()
+
+	// Admin mendapat semua permission
+	for _, permission := range model.Permissions {
+		_, err := db.Exec(`
+			INSERT INTO role_permissions (id, role_id, permission, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (role_id, permission) DO NOTHING
+		`, uuid.NewString(), adminRoleID, permission, now, now)
+
+		if err != nil {
+			return fmt.Errorf("failed seeding role permissions: %v", err)
+		}
+	}
+
+	fmt.Println("✅ Role Permissions seeder successfully")
+	return nil
+}
@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sync"
+	"todo-app/internal/entity"
+	"todo-app/internal/repository"
+	"todo-app/internal/util/helper"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// auditRedacted menggantikan nilai kolom rahasia, audit hanya mencatat bahwa kolom itu berubah
+const auditRedacted = "[REDACTED]"
+
+var auditSensitiveColumns = map[string]bool{
+	"password":      true,
+	"token":         true,
+	"token_hash":    true,
+	"totp_secret":   true,
+	"state_hash":    true,
+	"code_hash":     true,
+	"code_verifier": true,
+	"nonce":         true,
+}
+
+// Kolom yang selalu berubah dan tidak menambah informasi
+var auditIgnoredColumns = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+}
+
+var auditSchemaCache = &sync.Map{}
+
+// recordAudit writes an audit entry in the same transaction as the change, so both commit or roll back together.
+// Pass nil before for a create and nil after for a delete; for an update only the changed columns are kept.
+// The entity type and id come from the entity's table name and primary key.
+func recordAudit(ctx context.Context, tx *gorm.DB, auditLogRepository *repository.AuditLogRepository,
+	action string, before any, after any) error {
+	subject := after
+	if subject == nil {
+		subject = before
+	}
+	if subject == nil {
+		return errors.New("audit entry needs an entity")
+	}
+
+	subjectSchema, err := schema.Parse(subject, auditSchemaCache, tx.NamingStrategy)
+	if err != nil {
+		return err
+	}
+
+	beforeColumns := auditColumns(ctx, subjectSchema, before)
+	afterColumns := auditColumns(ctx, subjectSchema, after)
+	if beforeColumns != nil && afterColumns != nil {
+		for column, value := range beforeColumns {
+			if reflect.DeepEqual(value, afterColumns[column]) {
+				delete(beforeColumns, column)
+				delete(afterColumns, column)
+			}
+		}
+	}
+	redactColumns(beforeColumns)
+	redactColumns(afterColumns)
+
+	auditContext := helper.GetAuditContext(ctx)
+	auditLog := &entity.AuditLog{
+		ID:         uuid.NewString(),
+		Action:     action,
+		EntityType: subjectSchema.Table,
+		EntityId:   auditEntityId(ctx, subjectSchema, subject),
+		IpAddress:  auditContext.IpAddress,
+		RequestId:  auditContext.RequestId,
+	}
+
+	// Login dan registrasi terjadi sebelum ada actor di context, actor-nya adalah user itu sendiri
+	actorId := auditContext.ActorId
+	if actorId == "" {
+		actorId = auditSubjectUserId(ctx, subjectSchema, subject)
+	}
+	if actorId != "" {
+		auditLog.ActorId = &actorId
+	}
+
+	if auditLog.BeforeData, err = auditJSON(beforeColumns); err != nil {
+		return err
+	}
+	if auditLog.AfterData, err = auditJSON(afterColumns); err != nil {
+		return err
+	}
+
+	return auditLogRepository.Create(tx, auditLog)
+}
+
+// auditColumns membaca nilai setiap kolom entity berdasarkan nama kolom database
+func auditColumns(ctx context.Context, entitySchema *schema.Schema, value any) map[string]any {
+	if value == nil {
+		return nil
+	}
+
+	reflectValue := reflect.Indirect(reflect.ValueOf(value))
+	columns := make(map[string]any, len(entitySchema.DBNames))
+	for _, field := range entitySchema.Fields {
+		if field.DBName == "" || auditIgnoredColumns[field.DBName] {
+			continue
+		}
+		fieldValue, _ := field.ValueOf(ctx, reflectValue)
+		columns[field.DBName] = normalizeAuditValue(fieldValue)
+	}
+	return columns
+}
+
+// normalizeAuditValue mengubah pointer dan gorm.DeletedAt menjadi nilai JSON biasa supaya bisa dibandingkan
+func normalizeAuditValue(value any) any {
+	if deletedAt, ok := value.(gorm.DeletedAt); ok {
+		if !deletedAt.Valid {
+			return nil
+		}
+		return deletedAt.Time
+	}
+
+	reflectValue := reflect.ValueOf(value)
+	if reflectValue.Kind() == reflect.Pointer {
+		if reflectValue.IsNil() {
+			return nil
+		}
+		return reflectValue.Elem().Interface()
+	}
+	return value
+}
+
+func redactColumns(columns map[string]any) {
+	for column := range columns {
+		if auditSensitiveColumns[column] {
+			columns[column] = auditRedacted
+		}
+	}
+}
+
+func auditJSON(columns map[string]any) (*string, error) {
+	if columns == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(columns)
+	if err != nil {
+		return nil, err
+	}
+	value := string(data)
+	return &value, nil
+}
+
+func auditEntityId(ctx context.Context, entitySchema *schema.Schema, value any) string {
+	if entitySchema.PrioritizedPrimaryField == nil {
+		return ""
+	}
+	id, _ := entitySchema.PrioritizedPrimaryField.ValueOf(ctx, reflect.Indirect(reflect.ValueOf(value)))
+	if id, ok := id.(string); ok {
+		return id
+	}
+	return ""
+}
+
+func auditSubjectUserId(ctx context.Context, entitySchema *schema.Schema, value any) string {
+	if field := entitySchema.LookUpField("user_id"); field != nil {
+		userId, _ := field.ValueOf(ctx, reflect.Indirect(reflect.ValueOf(value)))
+		if userId, ok := userId.(string); ok {
+			return userId
+		}
+	}
+	if entitySchema.Table == "users" {
+		return auditEntityId(ctx, entitySchema, value)
+	}
+	return ""
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strings"
+	"time"
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+	"todo-app/internal/model/converter"
+	"todo-app/internal/repository"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const auditExportBatchSize = 500
+
+type AuditLogUseCase struct {
+	DB                 *gorm.DB
+	Log                *logrus.Logger
+	Validate           *validator.Validate
+	AuditLogRepository *repository.AuditLogRepository
+}
+
+func NewAuditLogUseCase(db *gorm.DB, logger *logrus.Logger, validate *validator.Validate,
+	auditLogRepository *repository.AuditLogRepository) *AuditLogUseCase {
+	return &AuditLogUseCase{
+		DB:                 db,
+		Log:                logger,
+		Validate:           validate,
+		AuditLogRepository: auditLogRepository,
+	}
+}
+
+func (c *AuditLogUseCase) Search(ctx context.Context, request *model.SearchAuditLogRequest) ([]model.AuditLogResponse, int64, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	auditLogs, total, err := c.AuditLogRepository.Search(tx, request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting audit logs")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error committing transaction")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.AuditLogResponse, len(auditLogs))
+	for i, auditLog := range auditLogs {
+		responses[i] = *converter.AuditLogToResponse(&auditLog)
+	}
+
+	return responses, total, nil
+}
+
+// Export validates the filter and returns a function that streams the matching entries as CSV, oldest first.
+// Paging fields of the request are ignored.
+func (c *AuditLogUseCase) Export(ctx context.Context, request *model.SearchAuditLogRequest) (func(w io.Writer) error, error) {
+	request.Page, request.Size = 1, 1
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	return func(w io.Writer) error {
+		writer := csv.NewWriter(w)
+		if err := writer.Write([]string{"created_at", "id", "actor_id", "action", "entity_type", "entity_id",
+			"before", "after", "ip_address", "request_id"}); err != nil {
+			return err
+		}
+
+		var cursor *entity.AuditLog
+		for {
+			auditLogs, err := c.AuditLogRepository.FindAfter(c.DB.WithContext(ctx), request, cursor, auditExportBatchSize)
+			if err != nil {
+				c.Log.WithError(err).Error("error exporting audit logs")
+				return err
+			}
+
+			for _, auditLog := range auditLogs {
+				if err := writer.Write(auditLogRecord(&auditLog)); err != nil {
+					return err
+				}
+			}
+			writer.Flush()
+			if err := writer.Error(); err != nil {
+				return err
+			}
+
+			if len(auditLogs) < auditExportBatchSize {
+				return nil
+			}
+			cursor = &auditLogs[len(auditLogs)-1]
+		}
+	}, nil
+}
+
+func auditLogRecord(auditLog *entity.AuditLog) []string {
+	var actorId, before, after string
+	if auditLog.ActorId != nil {
+		actorId = *auditLog.ActorId
+	}
+	if auditLog.BeforeData != nil {
+		before = *auditLog.BeforeData
+	}
+	if auditLog.AfterData != nil {
+		after = *auditLog.AfterData
+	}
+
+	return []string{
+		auditLog.CreatedAt.UTC().Format(time.RFC3339Nano),
+		auditLog.ID,
+		csvSafe(actorId),
+		auditLog.Action,
+		csvSafe(auditLog.EntityType),
+		csvSafe(auditLog.EntityId),
+		csvSafe(before),
+		csvSafe(after),
+		csvSafe(auditLog.IpAddress),
+		csvSafe(auditLog.RequestId),
+	}
+}
+
+// csvSafe mencegah formula injection ketika CSV dibuka di spreadsheet
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"context"
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+	"todo-app/internal/model/converter"
+	"todo-app/internal/repository"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type BoardUseCase struct {
+	DB                    *gorm.DB
+	Log                   *logrus.Logger
+	Validate              *validator.Validate
+	BoardRepository       *repository.BoardRepository
+	ProjectUserRepository *repository.ProjectUserRepository
+	AuditLogRepository    *repository.AuditLogRepository
+}
+
+func NewBoardUseCase(db *gorm.DB, logger *logrus.Logger, validate *validator.Validate,
+	boardRepository *repository.BoardRepository, projectUserRepository *repository.ProjectUserRepository,
+	auditLogRepository *repository.AuditLogRepository) *BoardUseCase {
+	return &BoardUseCase{
+		DB:                    db,
+		Log:                   logger,
+		Validate:              validate,
+		BoardRepository:       boardRepository,
+		ProjectUserRepository: projectUserRepository,
+		AuditLogRepository:    auditLogRepository,
+	}
+}
+
+func (c *BoardUseCase) Create(ctx context.Context, request *model.CreateBoardRequest) (*model.BoardResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	if _, err := checkProjectAccess(tx, c.Log, c.ProjectUserRepository, request.ProjectId, request.UserId, projectWriteRoles...); err != nil {
+		return nil, err
+	}
+
+	board := &entity.Board{
+		ID:        uuid.New().String(),
+		ProjectId: request.ProjectId,
+		Name:      request.Name,
+	}
+
+	if err := c.BoardRepository.Create(tx, board); err != nil {
+		c.Log.WithError(err).Error("error creating board")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionCreate, nil, board); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error creating board")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.BoardToResponse(board), nil
+}
+
+func (c *BoardUseCase) Update(ctx context.Context, request *model.UpdateBoardRequest) (*model.BoardResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	if _, err := checkProjectAccess(tx, c.Log, c.ProjectUserRepository, request.ProjectId, request.UserId, projectWriteRoles...); err != nil {
+		return nil, err
+	}
+
+	board := new(entity.Board)
+	if err := c.BoardRepository.FindByIdAndProjectId(tx, board, request.ID, request.ProjectId); err != nil {
+		c.Log.WithError(err).Error("error getting board")
+		return nil, fiber.ErrNotFound
+	}
+
+	before := *board
+	board.Name = request.Name
+
+	if err := c.BoardRepository.Update(tx, board); err != nil {
+		c.Log.WithError(err).Error("error updating board")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionUpdate, &before, board); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error updating board")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.BoardToResponse(board), nil
+}
+
+func (c *BoardUseCase) Get(ctx context.Context, request *model.GetBoardRequest) (*model.BoardResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	if _, err := checkProjectAccess(tx, c.Log, c.ProjectUserRepository, request.ProjectId, request.UserId); err != nil {
+		return nil, err
+	}
+
+	board := new(entity.Board)
+	if err := c.BoardRepository.FindByIdAndProjectId(tx, board, request.ID, request.ProjectId); err != nil {
+		c.Log.WithError(err).Error("error getting board")
+		return nil, fiber.ErrNotFound
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error getting board")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.BoardToResponse(board), nil
+}
+
+func (c *BoardUseCase) SoftDelete(ctx context.Context, request *model.GetBoardRequest) (*model.BoardResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	if _, err := checkProjectAccess(tx, c.Log, c.ProjectUserRepository, request.ProjectId, request.UserId, projectWriteRoles...); err != nil {
+		return nil, err
+	}
+
+	board := new(entity.Board)
+	if err := c.BoardRepository.FindByIdAndProjectId(tx, board, request.ID, request.ProjectId); err != nil {
+		c.Log.WithError(err).Error("error getting board")
+		return nil, fiber.ErrNotFound
+	}
+
+	before := *board
+	if err := c.BoardRepository.SoftDelete(tx, board); err != nil {
+		c.Log.WithError(err).Error("error soft deleting board")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionDelete, &before, board); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error soft deleting board")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.BoardToResponse(board), nil
+}
+
+func (c *BoardUseCase) RecycleBin(ctx context.Context, request *model.SearchBoardRequest) ([]model.BoardResponse, int64, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	if _, err := checkProjectAccess(tx, c.Log, c.ProjectUserRepository, request.ProjectId, request.UserId); err != nil {
+		return nil, 0, err
+	}
+
+	boards, total, err := c.BoardRepository.SearchTrashed(tx, request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting trashed boards")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error committing trashed boards")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.BoardResponse, len(boards))
+	for i, board := range boards {
+		responses[i] = *converter.BoardToResponse(&board)
+	}
+
+	return responses, total, nil
+}
+
+func (c *BoardUseCase) Restore(ctx context.Context, request *model.GetBoardRequest) (*model.BoardResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	if _, err := checkProjectAccess(tx, c.Log, c.ProjectUserRepository, request.ProjectId, request.UserId, projectWriteRoles...); err != nil {
+		return nil, err
+	}
+
+	if err := c.BoardRepository.Restore(tx, request.ID, request.ProjectId); err != nil {
+		c.Log.WithError(err).Error("error restoring board")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	board := new(entity.Board)
+	if err := c.BoardRepository.FindByIdAndProjectId(tx, board, request.ID, request.ProjectId); err != nil {
+		c.Log.WithError(err).Error("error getting board")
+		return nil, fiber.ErrNotFound
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionRestore, nil, board); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error restoring board")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.BoardToResponse(board), nil
+}
+
+func (c *BoardUseCase) Search(ctx context.Context, request *model.SearchBoardRequest) ([]model.BoardResponse, int64, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	if _, err := checkProjectAccess(tx, c.Log, c.ProjectUserRepository, request.ProjectId, request.UserId); err != nil {
+		return nil, 0, err
+	}
+
+	boards, total, err := c.BoardRepository.Search(tx, request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting boards")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error getting boards")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.BoardResponse, len(boards))
+	for i, board := range boards {
+		responses[i] = *converter.BoardToResponse(&board)
+	}
+
+	return responses, total, nil
+}
@@ -0,0 +1,370 @@
+package usecase
+
+import (
+	"context"
+	"todo-app/internal/entity"
+	"todo-app/internal/gateway/messaging"
+	"todo-app/internal/model"
+	"todo-app/internal/model/converter"
+	"todo-app/internal/repository"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type CardUseCase struct {
+	DB                    *gorm.DB
+	Log                   *logrus.Logger
+	Validate              *validator.Validate
+	CardRepository        *repository.CardRepository
+	BoardRepository       *repository.BoardRepository
+	ProjectUserRepository *repository.ProjectUserRepository
+	AuditLogRepository    *repository.AuditLogRepository
+	OutboxEventRepository *repository.OutboxEventRepository
+}
+
+func NewCardUseCase(db *gorm.DB, logger *logrus.Logger, validate *validator.Validate,
+	cardRepository *repository.CardRepository, boardRepository *repository.BoardRepository,
+	projectUserRepository *repository.ProjectUserRepository, auditLogRepository *repository.AuditLogRepository,
+	outboxEventRepository *repository.OutboxEventRepository) *CardUseCase {
+	return &CardUseCase{
+		DB:                    db,
+		Log:                   logger,
+		Validate:              validate,
+		CardRepository:        cardRepository,
+		BoardRepository:       boardRepository,
+		ProjectUserRepository: projectUserRepository,
+		AuditLogRepository:    auditLogRepository,
+		OutboxEventRepository: outboxEventRepository,
+	}
+}
+
+// assignee converts an optional assignee id into the nullable column value,
+// only accepting users that are members of the project
+func (c *CardUseCase) assignee(tx *gorm.DB, projectId string, assigneeId string) (*string, error) {
+	if assigneeId == "" {
+		return nil, nil
+	}
+
+	isMember, err := c.ProjectUserRepository.IsMember(tx, projectId, assigneeId)
+	if err != nil {
+		c.Log.WithError(err).Error("error checking assignee membership")
+		return nil, fiber.ErrInternalServerError
+	}
+	if !isMember {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "assignee is not a member of the project")
+	}
+
+	return &assigneeId, nil
+}
+
+func (c *CardUseCase) Create(ctx context.Context, request *model.CreateCardRequest) (*model.CardResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	if _, err := checkProjectAccess(tx, c.Log, c.ProjectUserRepository, request.ProjectId, request.UserId, projectWriteRoles...); err != nil {
+		return nil, err
+	}
+
+	board := new(entity.Board)
+	if err := c.BoardRepository.FindByIdAndProjectId(tx, board, request.BoardId, request.ProjectId); err != nil {
+		c.Log.WithError(err).Error("error getting board")
+		return nil, fiber.ErrNotFound
+	}
+
+	assigneeId, err := c.assignee(tx, request.ProjectId, request.AssigneeId)
+	if err != nil {
+		return nil, err
+	}
+
+	card := &entity.Card{
+		ID:      uuid.New().String(),
+		BoardId: board.ID,
+		UserId:  assigneeId,
+		Name:    request.Name,
+	}
+
+	if err := c.CardRepository.Create(tx, card); err != nil {
+		c.Log.WithError(err).Error("error creating card")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionCreate, nil, card); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error creating card")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.CardToResponse(card), nil
+}
+
+func (c *CardUseCase) Get(ctx context.Context, request *model.GetCardRequest) (*model.CardResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	if _, err := checkProjectAccess(tx, c.Log, c.ProjectUserRepository, request.ProjectId, request.UserId); err != nil {
+		return nil, err
+	}
+
+	card := new(entity.Card)
+	if err := c.CardRepository.FindByIdAndProjectId(tx, card, request.ID, request.ProjectId); err != nil {
+		c.Log.WithError(err).Error("error getting card")
+		return nil, fiber.ErrNotFound
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error getting card")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.CardToResponse(card), nil
+}
+
+func (c *CardUseCase) Move(ctx context.Context, request *model.MoveCardRequest) (*model.CardResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	if _, err := checkProjectAccess(tx, c.Log, c.ProjectUserRepository, request.ProjectId, request.UserId, projectWriteRoles...); err != nil {
+		return nil, err
+	}
+
+	card := new(entity.Card)
+	if err := c.CardRepository.FindByIdAndProjectId(tx, card, request.ID, request.ProjectId); err != nil {
+		c.Log.WithError(err).Error("error getting card")
+		return nil, fiber.ErrNotFound
+	}
+
+	// The target board has to live in the same project as the card
+	board := new(entity.Board)
+	if err := c.BoardRepository.FindByIdAndProjectId(tx, board, request.BoardId, request.ProjectId); err != nil {
+		c.Log.WithError(err).Error("error getting target board")
+		return nil, fiber.NewError(fiber.StatusBadRequest, "target board not found in project")
+	}
+
+	before := *card
+	card.BoardId = board.ID
+
+	if err := c.CardRepository.Update(tx, card); err != nil {
+		c.Log.WithError(err).Error("error moving card")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionUpdate, &before, card); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if card.BoardId != before.BoardId {
+		event := converter.CardToMovedEvent(card, request.ProjectId, before.BoardId, request.UserId)
+		if err := recordEvent(ctx, tx, c.OutboxEventRepository, messaging.TopicCardMoved, event); err != nil {
+			c.Log.WithError(err).Error("error writing card moved event")
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error moving card")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.CardToResponse(card), nil
+}
+
+func (c *CardUseCase) Assign(ctx context.Context, request *model.AssignCardRequest) (*model.CardResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	if _, err := checkProjectAccess(tx, c.Log, c.ProjectUserRepository, request.ProjectId, request.UserId, projectWriteRoles...); err != nil {
+		return nil, err
+	}
+
+	card := new(entity.Card)
+	if err := c.CardRepository.FindByIdAndProjectId(tx, card, request.ID, request.ProjectId); err != nil {
+		c.Log.WithError(err).Error("error getting card")
+		return nil, fiber.ErrNotFound
+	}
+
+	assigneeId, err := c.assignee(tx, request.ProjectId, request.AssigneeId)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *card
+	card.UserId = assigneeId
+
+	if err := c.CardRepository.Update(tx, card); err != nil {
+		c.Log.WithError(err).Error("error assigning card")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionUpdate, &before, card); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error assigning card")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.CardToResponse(card), nil
+}
+
+func (c *CardUseCase) Close(ctx context.Context, request *model.GetCardRequest) (*model.CardResponse, error) {
+	return c.setClosed(ctx, request, true)
+}
+
+func (c *CardUseCase) Reopen(ctx context.Context, request *model.GetCardRequest) (*model.CardResponse, error) {
+	return c.setClosed(ctx, request, false)
+}
+
+func (c *CardUseCase) setClosed(ctx context.Context, request *model.GetCardRequest, closed bool) (*model.CardResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	if _, err := checkProjectAccess(tx, c.Log, c.ProjectUserRepository, request.ProjectId, request.UserId, projectWriteRoles...); err != nil {
+		return nil, err
+	}
+
+	card := new(entity.Card)
+	if err := c.CardRepository.FindByIdAndProjectId(tx, card, request.ID, request.ProjectId); err != nil {
+		c.Log.WithError(err).Error("error getting card")
+		return nil, fiber.ErrNotFound
+	}
+
+	before := *card
+	card.IsClosed = closed
+
+	if err := c.CardRepository.Update(tx, card); err != nil {
+		c.Log.WithError(err).Error("error updating card status")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionUpdate, &before, card); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if card.IsClosed && !before.IsClosed {
+		event := converter.CardToClosedEvent(card, request.ProjectId, request.UserId)
+		if err := recordEvent(ctx, tx, c.OutboxEventRepository, messaging.TopicCardClosed, event); err != nil {
+			c.Log.WithError(err).Error("error writing card closed event")
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error updating card status")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.CardToResponse(card), nil
+}
+
+func (c *CardUseCase) SoftDelete(ctx context.Context, request *model.GetCardRequest) (*model.CardResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	if _, err := checkProjectAccess(tx, c.Log, c.ProjectUserRepository, request.ProjectId, request.UserId, projectWriteRoles...); err != nil {
+		return nil, err
+	}
+
+	card := new(entity.Card)
+	if err := c.CardRepository.FindByIdAndProjectId(tx, card, request.ID, request.ProjectId); err != nil {
+		c.Log.WithError(err).Error("error getting card")
+		return nil, fiber.ErrNotFound
+	}
+
+	before := *card
+	if err := c.CardRepository.SoftDelete(tx, card); err != nil {
+		c.Log.WithError(err).Error("error soft deleting card")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionDelete, &before, card); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error soft deleting card")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.CardToResponse(card), nil
+}
+
+func (c *CardUseCase) Search(ctx context.Context, request *model.SearchCardRequest) ([]model.CardResponse, int64, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	if _, err := checkProjectAccess(tx, c.Log, c.ProjectUserRepository, request.ProjectId, request.UserId); err != nil {
+		return nil, 0, err
+	}
+
+	board := new(entity.Board)
+	if err := c.BoardRepository.FindByIdAndProjectId(tx, board, request.BoardId, request.ProjectId); err != nil {
+		c.Log.WithError(err).Error("error getting board")
+		return nil, 0, fiber.ErrNotFound
+	}
+
+	cards, total, err := c.CardRepository.Search(tx, request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting cards")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error getting cards")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.CardResponse, len(cards))
+	for i, card := range cards {
+		responses[i] = *converter.CardToResponse(&card)
+	}
+
+	return responses, total, nil
+}
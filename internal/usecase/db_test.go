@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"database/sql"
+	"io"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"todo-app/internal/entity"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const testDriverName = "sqlite3_usecase_test"
+
+var registerTestDriver sync.Once
+
+// advisoryLockHeld meniru worker lain yang memegang pg advisory lock
+var advisoryLockHeld atomic.Bool
+
+// newTestDB membuka database sqlite baru dengan tabel dari semua entity.
+// Clause FOR UPDATE diabaikan sqlite, pg_try_advisory_xact_lock didaftarkan sebagai fungsi yang gagal selama advisoryLockHeld true.
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	registerTestDriver.Do(func() {
+		sql.Register(testDriverName, &sqlite3.SQLiteDriver{
+			ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+				return conn.RegisterFunc("pg_try_advisory_xact_lock", func(key int64) bool {
+					return !advisoryLockHeld.Load()
+				}, false)
+			},
+		})
+	})
+
+	dsn := filepath.Join(t.TempDir(), "test.db") + "?_busy_timeout=5000&_journal_mode=WAL"
+	db, err := gorm.Open(sqlite.Dialector{DriverName: testDriverName, DSN: dsn}, &gorm.Config{
+		Logger: logger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+
+	err = db.AutoMigrate(
+		&entity.AuditLog{}, &entity.Board{}, &entity.Card{}, &entity.Department{}, &entity.LoginThrottle{},
+		&entity.OidcLoginState{}, &entity.OutboxEvent{}, &entity.PersonalAccessToken{}, &entity.ProcessedEvent{},
+		&entity.Project{}, &entity.ProjectUser{}, &entity.RefreshToken{}, &entity.Role{}, &entity.RolePermission{},
+		&entity.TokenRevocation{}, &entity.User{}, &entity.UserIdentity{}, &entity.UserMfa{},
+		&entity.UserRecoveryCode{}, &entity.UserSession{}, &entity.UserToken{},
+	)
+	if err != nil {
+		t.Fatalf("migrate sqlite: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+func newTestLogger() *logrus.Logger {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	return log
+}
@@ -0,0 +1,373 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+	"todo-app/internal/model/converter"
+	"todo-app/internal/repository"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type DepartmentUseCase struct {
+	DB                   *gorm.DB
+	Log                  *logrus.Logger
+	Validate             *validator.Validate
+	DepartmentRepository *repository.DepartmentRepository
+	UserRepository       *repository.UserRepository
+	ProjectRepository    *repository.ProjectRepository
+	AuditLogRepository   *repository.AuditLogRepository
+}
+
+func NewDepartmentUseCase(db *gorm.DB, logger *logrus.Logger, validate *validator.Validate,
+	departmentRepository *repository.DepartmentRepository, userRepository *repository.UserRepository,
+	projectRepository *repository.ProjectRepository, auditLogRepository *repository.AuditLogRepository) *DepartmentUseCase {
+	return &DepartmentUseCase{
+		DB:                   db,
+		Log:                  logger,
+		Validate:             validate,
+		DepartmentRepository: departmentRepository,
+		UserRepository:       userRepository,
+		ProjectRepository:    projectRepository,
+		AuditLogRepository:   auditLogRepository,
+	}
+}
+
+// checkInUse refuses to remove a department that still owns users or projects.
+// Pass an unscoped tx to also count soft deleted users, which a hard delete would cascade to.
+func (c *DepartmentUseCase) checkInUse(userTx *gorm.DB, projectTx *gorm.DB, id string) error {
+	users, err := c.UserRepository.CountByDepartmentId(userTx, id)
+	if err != nil {
+		c.Log.WithError(err).Error("error counting department users")
+		return fiber.ErrInternalServerError
+	}
+	if users > 0 {
+		return fiber.NewError(fiber.StatusConflict, "department still has users")
+	}
+
+	projects, err := c.ProjectRepository.CountByDepartmentId(projectTx, id)
+	if err != nil {
+		c.Log.WithError(err).Error("error counting department projects")
+		return fiber.ErrInternalServerError
+	}
+	if projects > 0 {
+		return fiber.NewError(fiber.StatusConflict, "department still has projects")
+	}
+
+	return nil
+}
+
+func (c *DepartmentUseCase) Create(ctx context.Context, request *model.CreateDepartmentRequest) (*model.DepartmentResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	department := &entity.Department{
+		ID:   uuid.New().String(),
+		Name: request.Name,
+	}
+
+	if err := c.DepartmentRepository.Create(tx, department); err != nil {
+		c.Log.WithError(err).Error("error creating department")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionCreate, nil, department); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error creating department")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.DepartmentToResponse(department), nil
+}
+
+func (c *DepartmentUseCase) Update(ctx context.Context, request *model.UpdateDepartmentRequest) (*model.DepartmentResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	department := new(entity.Department)
+	if err := c.DepartmentRepository.FindById(tx, department, request.ID); err != nil {
+		c.Log.WithError(err).Error("error getting department")
+		return nil, fiber.ErrNotFound
+	}
+
+	before := *department
+	department.Name = request.Name
+
+	if err := c.DepartmentRepository.Update(tx, department); err != nil {
+		c.Log.WithError(err).Error("error updating department")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionUpdate, &before, department); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error updating department")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.DepartmentToResponse(department), nil
+}
+
+func (c *DepartmentUseCase) Get(ctx context.Context, request *model.GetDepartmentRequest) (*model.DepartmentResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	department := new(entity.Department)
+	if err := c.DepartmentRepository.FindById(tx, department, request.ID); err != nil {
+		c.Log.WithError(err).Error("error getting department")
+		return nil, fiber.ErrNotFound
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error getting department")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.DepartmentToResponse(department), nil
+}
+
+func (c *DepartmentUseCase) SoftDelete(ctx context.Context, request *model.GetDepartmentRequest) (*model.DepartmentResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	department := new(entity.Department)
+	if err := c.DepartmentRepository.FindById(tx, department, request.ID); err != nil {
+		c.Log.WithError(err).Error("error getting department")
+		return nil, fiber.ErrNotFound
+	}
+
+	if err := c.checkInUse(tx, tx, department.ID); err != nil {
+		return nil, err
+	}
+
+	before := *department
+	if err := c.DepartmentRepository.SoftDelete(tx, department); err != nil {
+		c.Log.WithError(err).Error("error soft deleting department")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionDelete, &before, department); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error soft deleting department")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.DepartmentToResponse(department), nil
+}
+
+func (c *DepartmentUseCase) RecycleBin(ctx context.Context, request *model.SearchDepartmentRequest) ([]model.DepartmentResponse, int64, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	departments, total, err := c.DepartmentRepository.SearchTrashed(tx, request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting trashed departments")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error committing trashed departments")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.DepartmentResponse, len(departments))
+	for i, department := range departments {
+		responses[i] = *converter.DepartmentToResponse(&department)
+	}
+
+	return responses, total, nil
+}
+
+func (c *DepartmentUseCase) Restore(ctx context.Context, request *model.GetDepartmentRequest) (*model.DepartmentResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	if err := c.DepartmentRepository.Restore(tx, request.ID); err != nil {
+		c.Log.WithError(err).Error("error restoring department")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	department := new(entity.Department)
+	if err := c.DepartmentRepository.FindById(tx, department, request.ID); err != nil {
+		c.Log.WithError(err).Error("error getting department")
+		return nil, fiber.ErrNotFound
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionRestore, nil, department); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error restoring department")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.DepartmentToResponse(department), nil
+}
+
+func (c *DepartmentUseCase) ForceDelete(ctx context.Context, request *model.DeleteDepartmentRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return fiber.ErrBadRequest
+	}
+
+	// users cascade on hard delete, so soft deleted users count too
+	if err := c.checkInUse(tx.Unscoped(), tx, request.ID); err != nil {
+		return err
+	}
+
+	department := new(entity.Department)
+	if err := c.DepartmentRepository.FindById(tx.Unscoped(), department, request.ID); err != nil {
+		c.Log.WithError(err).Error("error getting department")
+		return fiber.ErrNotFound
+	}
+
+	if err := c.DepartmentRepository.ForceDelete(tx, request.ID); err != nil {
+		c.Log.WithError(err).Error("error force deleting department")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionForceDelete, department, nil); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error force deleting department")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (c *DepartmentUseCase) Search(ctx context.Context, request *model.SearchDepartmentRequest) ([]model.DepartmentResponse, int64, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	departments, total, err := c.DepartmentRepository.Search(tx, request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting departments")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error getting departments")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.DepartmentResponse, len(departments))
+	for i, department := range departments {
+		responses[i] = *converter.DepartmentToResponse(&department)
+	}
+
+	return responses, total, nil
+}
+
+func (c *DepartmentUseCase) MoveUsers(ctx context.Context, request *model.MoveDepartmentUsersRequest) ([]model.UserResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	department := new(entity.Department)
+	if err := c.DepartmentRepository.FindById(tx, department, request.ID); err != nil {
+		c.Log.WithError(err).Error("error getting department")
+		return nil, fiber.ErrNotFound
+	}
+
+	// Id yang dikirim dua kali hanya dihitung sekali, jumlah user yang ditemukan dibandingkan dengan id yang unik
+	userIds := slices.Compact(slices.Sorted(slices.Values(request.UserIds)))
+	users, err := c.UserRepository.FindByIds(tx, userIds)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting users")
+		return nil, fiber.ErrInternalServerError
+	}
+	if len(users) != len(userIds) {
+		return nil, fiber.NewError(fiber.StatusNotFound, "one or more users not found")
+	}
+
+	if err := c.UserRepository.UpdateDepartment(tx, userIds, department.ID); err != nil {
+		c.Log.WithError(err).Error("error moving users to department")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	for i := range users {
+		before := users[i]
+		users[i].DepartementId = department.ID
+		if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionUpdate, &before, &users[i]); err != nil {
+			c.Log.WithError(err).Error("error writing audit log")
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error moving users to department")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.UserResponse, len(users))
+	for i, user := range users {
+		responses[i] = *converter.UserToResponse(&user)
+	}
+
+	return responses, nil
+}
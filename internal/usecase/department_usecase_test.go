@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+	"todo-app/internal/repository"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func TestMoveUsersAcceptsDuplicateIds(t *testing.T) {
+	db := newTestDB(t)
+	log := newTestLogger()
+	useCase := NewDepartmentUseCase(db, log, validator.New(), repository.NewDepartmentRepository(log),
+		repository.NewUserRepository(log), repository.NewProjectRepository(log), repository.NewAuditLogRepository(log))
+
+	from, to := uuid.NewString(), uuid.NewString()
+	db.Create(&[]entity.Department{{ID: from, Name: "default"}, {ID: to, Name: "engineering"}})
+	users := []entity.User{
+		{ID: uuid.NewString(), Email: "jane@example.com", Name: "Jane", DepartementId: from},
+		{ID: uuid.NewString(), Email: "john@example.com", Name: "John", DepartementId: from},
+	}
+	if err := db.Create(&users).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	responses, err := useCase.MoveUsers(context.Background(), &model.MoveDepartmentUsersRequest{
+		ID: to, UserIds: []string{users[0].ID, users[1].ID, users[0].ID},
+	})
+	if err != nil {
+		t.Fatalf("MoveUsers returned %v", err)
+	}
+	if len(responses) != 2 {
+		t.Fatalf("MoveUsers returned %d users, want 2", len(responses))
+	}
+
+	var moved int64
+	db.Model(new(entity.User)).Where("department_id = ?", to).Count(&moved)
+	if moved != 2 {
+		t.Fatalf("%d users moved, want 2", moved)
+	}
+
+	// Id yang tidak ada tetap ditolak walaupun jumlah id sama dengan jumlah user yang ditemukan
+	_, err = useCase.MoveUsers(context.Background(), &model.MoveDepartmentUsersRequest{
+		ID: from, UserIds: []string{users[0].ID, users[0].ID, uuid.NewString()},
+	})
+	assertStatus(t, err, fiber.StatusNotFound)
+}
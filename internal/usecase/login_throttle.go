@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"strings"
+	"time"
+	"todo-app/internal/entity"
+	"todo-app/internal/repository"
+	"todo-app/internal/util/helper"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// LoginThrottleConfig holds the brute-force limits, loaded from viper in config.NewLoginThrottleConfig
+type LoginThrottleConfig struct {
+	MaxAttempts     int
+	IpMaxAttempts   int
+	BackoffBase     time.Duration
+	BackoffMax      time.Duration
+	LockoutDuration time.Duration
+	FailureWindow   time.Duration
+}
+
+var errTooManyLoginAttempts = fiber.NewError(fiber.StatusTooManyRequests, "too many failed login attempts, try again later")
+
+const accountThrottleKeyPrefix = "account:"
+
+func accountThrottleKey(email string) string {
+	return accountThrottleKeyPrefix + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipThrottleKey(ip string) string {
+	return "ip:" + ip
+}
+
+// loggedThrottleKey menyamarkan email di key akun supaya log tidak memuat alamat email,
+// hash yang dipotong tetap cukup untuk mencocokkan baris log dari akun yang sama
+func loggedThrottleKey(key string) string {
+	email, ok := strings.CutPrefix(key, accountThrottleKeyPrefix)
+	if !ok {
+		return key
+	}
+	return accountThrottleKeyPrefix + helper.HashToken(email)[:12]
+}
+
+// loginThrottleLimit memasangkan key throttle dengan jumlah gagal yang membuat key tersebut terkunci
+type loginThrottleLimit struct {
+	Key         string
+	MaxAttempts int
+}
+
+// loginAttempt adalah percobaan login yang sudah dipesan, baris throttle-nya ditahan sampai dicatat gagal atau dilepas
+type loginAttempt struct {
+	limits    []loginThrottleLimit
+	throttles []entity.LoginThrottle
+	now       time.Time
+}
+
+func (a *loginAttempt) keys() []string {
+	keys := make([]string, len(a.throttles))
+	for i, throttle := range a.throttles {
+		keys[i] = throttle.Key
+	}
+	return keys
+}
+
+// reserveLoginAttempt memesan percobaan secara atomic sebelum password dibandingkan. Setiap key ditahan selama
+// BackoffBase, sehingga request paralel untuk akun atau IP yang sama ditolak sampai percobaan ini selesai.
+// Dijalankan di luar transaksi login supaya tahanan langsung terlihat oleh request lain.
+func reserveLoginAttempt(db *gorm.DB, log *logrus.Logger, loginThrottleRepository *repository.LoginThrottleRepository,
+	config *LoginThrottleConfig, limits []loginThrottleLimit, now time.Time) (*loginAttempt, error) {
+	attempt := &loginAttempt{limits: limits, now: now}
+	holdUntil := now.Add(config.BackoffBase)
+
+	for _, limit := range limits {
+		throttle := new(entity.LoginThrottle)
+		reserved, err := loginThrottleRepository.Reserve(db, throttle, limit.Key, now, holdUntil)
+		if err != nil {
+			log.Warnf("Failed reserve login attempt : %+v", err)
+			releaseLoginAttempt(db, log, loginThrottleRepository, attempt)
+			return nil, fiber.ErrInternalServerError
+		}
+		if !reserved {
+			log.Warnf("Login rejected, %s is locked or in backoff", loggedThrottleKey(limit.Key))
+			releaseLoginAttempt(db, log, loginThrottleRepository, attempt)
+			return nil, errTooManyLoginAttempts
+		}
+		attempt.throttles = append(attempt.throttles, *throttle)
+	}
+
+	return attempt, nil
+}
+
+// releaseLoginAttempt melepas tahanan percobaan yang tidak gagal
+func releaseLoginAttempt(db *gorm.DB, log *logrus.Logger, loginThrottleRepository *repository.LoginThrottleRepository, attempt *loginAttempt) {
+	if len(attempt.throttles) == 0 {
+		return
+	}
+	if err := loginThrottleRepository.Release(db, attempt.keys()); err != nil {
+		log.Warnf("Failed release login attempt : %+v", err)
+	}
+}
+
+// recordLoginFailure menambah counter gagal, menghitung backoff eksponensial, dan mengunci setelah batas tercapai.
+// Baris throttle masih ditahan oleh percobaan ini, jadi counter aman dihitung di sini lalu ditulis dalam satu update.
+// Dijalankan di luar transaksi login supaya counter tetap tersimpan walaupun login di-rollback.
+func recordLoginFailure(db *gorm.DB, log *logrus.Logger, loginThrottleRepository *repository.LoginThrottleRepository,
+	config *LoginThrottleConfig, attempt *loginAttempt) {
+	now := attempt.now
+	for i := range attempt.throttles {
+		throttle := &attempt.throttles[i]
+		if throttle.LastFailedAt.Before(now.Add(-config.FailureWindow)) {
+			throttle.FailedCount = 0
+		}
+		throttle.FailedCount++
+		throttle.LastFailedAt = now
+
+		if throttle.FailedCount >= attempt.limits[i].MaxAttempts {
+			lockedUntil := now.Add(config.LockoutDuration)
+			throttle.LockedUntil = &lockedUntil
+			throttle.NextAttemptAt = nil
+			throttle.FailedCount = 0
+			log.Warnf("Login locked for %s until %s", loggedThrottleKey(throttle.Key), lockedUntil.Format(time.RFC3339))
+		} else {
+			nextAttemptAt := now.Add(loginBackoff(config, throttle.FailedCount))
+			throttle.NextAttemptAt = &nextAttemptAt
+			throttle.LockedUntil = nil
+		}
+
+		if err := loginThrottleRepository.RecordFailure(db, throttle); err != nil {
+			log.Warnf("Failed record login failure : %+v", err)
+		}
+	}
+}
+
+// loginBackoff menghasilkan base * 2^(failedCount-1), dibatasi BackoffMax
+func loginBackoff(config *LoginThrottleConfig, failedCount int) time.Duration {
+	delay := config.BackoffBase
+	for i := 1; i < failedCount && delay < config.BackoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, config.BackoffMax)
+}
@@ -0,0 +1,377 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+	"todo-app/internal/repository"
+	"todo-app/internal/util/helper"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const recoveryCodeCount = 10
+
+// MfaConfig holds the authenticator issuer name and the lifetime of the login challenge, loaded from viper in config.NewMfaConfig
+type MfaConfig struct {
+	Issuer       string
+	ChallengeTTL time.Duration
+}
+
+type MfaUseCase struct {
+	DB                         *gorm.DB
+	Log                        *logrus.Logger
+	Validate                   *validator.Validate
+	Config                     *MfaConfig
+	UserMfaRepository          *repository.UserMfaRepository
+	UserRecoveryCodeRepository *repository.UserRecoveryCodeRepository
+	UserRepository             *repository.UserRepository
+	RoleRepository             *repository.RoleRepository
+	LoginThrottleRepository    *repository.LoginThrottleRepository
+	LoginThrottle              *LoginThrottleConfig
+	AuditLogRepository         *repository.AuditLogRepository
+}
+
+func NewMfaUseCase(db *gorm.DB, logger *logrus.Logger, validate *validator.Validate, config *MfaConfig,
+	userMfaRepository *repository.UserMfaRepository, userRecoveryCodeRepository *repository.UserRecoveryCodeRepository,
+	userRepository *repository.UserRepository, roleRepository *repository.RoleRepository,
+	loginThrottleRepository *repository.LoginThrottleRepository, loginThrottle *LoginThrottleConfig,
+	auditLogRepository *repository.AuditLogRepository) *MfaUseCase {
+	return &MfaUseCase{
+		DB:                         db,
+		Log:                        logger,
+		Validate:                   validate,
+		Config:                     config,
+		UserMfaRepository:          userMfaRepository,
+		UserRecoveryCodeRepository: userRecoveryCodeRepository,
+		UserRepository:             userRepository,
+		RoleRepository:             roleRepository,
+		LoginThrottleRepository:    loginThrottleRepository,
+		LoginThrottle:              loginThrottle,
+		AuditLogRepository:         auditLogRepository,
+	}
+}
+
+// verifyTotp mengecek kode TOTP dan menyimpan step yang dipakai supaya kode yang sama tidak bisa dipakai dua kali
+func verifyTotp(tx *gorm.DB, userMfaRepository *repository.UserMfaRepository, mfa *entity.UserMfa, code string, now time.Time) (bool, error) {
+	step, ok := helper.ValidateTotp(mfa.TotpSecret, code, now)
+	if !ok || step <= mfa.LastUsedStep {
+		return false, nil
+	}
+
+	mfa.LastUsedStep = step
+	if err := userMfaRepository.Update(tx, mfa); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// useRecoveryCode menandai recovery code sudah dipakai, setiap kode hanya berlaku sekali
+func useRecoveryCode(tx *gorm.DB, userRecoveryCodeRepository *repository.UserRecoveryCodeRepository, userId string, code string, now time.Time) (bool, error) {
+	recoveryCode := new(entity.UserRecoveryCode)
+	if err := userRecoveryCodeRepository.FindUnusedByHash(tx, recoveryCode, userId, helper.HashToken(helper.NormalizeRecoveryCode(code))); err != nil {
+		return false, nil
+	}
+
+	recoveryCode.UsedAt = &now
+	if err := userRecoveryCodeRepository.Update(tx, recoveryCode); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// mfaPending true kalau role user mewajibkan MFA tapi user belum menyelesaikan enrollment
+func mfaPending(tx *gorm.DB, roleRepository *repository.RoleRepository, userMfaRepository *repository.UserMfaRepository, user *entity.User) (bool, error) {
+	if user.RoleId == "" {
+		return false, nil
+	}
+
+	role := new(entity.Role)
+	if err := roleRepository.FindById(tx, role, user.RoleId); err != nil {
+		return false, nil
+	}
+	if !role.MfaRequired {
+		return false, nil
+	}
+
+	confirmed, err := userMfaRepository.IsConfirmed(tx, user.ID)
+	if err != nil {
+		return false, err
+	}
+	return !confirmed, nil
+}
+
+func (c *MfaUseCase) Enroll(ctx context.Context, request *model.EnrollMfaRequest) (*model.MfaEnrollResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.UserId); err != nil {
+		c.Log.WithError(err).Error("error getting user")
+		return nil, fiber.ErrNotFound
+	}
+
+	secret, err := helper.GenerateTotpSecret()
+	if err != nil {
+		c.Log.WithError(err).Error("error generating totp secret")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Enrollment yang belum dikonfirmasi boleh diulang, yang sudah aktif harus di-disable dulu
+	mfa := new(entity.UserMfa)
+	if err := c.UserMfaRepository.FindByUserId(tx, mfa, user.ID); err == nil {
+		if mfa.ConfirmedAt != nil {
+			return nil, fiber.NewError(fiber.StatusConflict, "mfa is already enabled")
+		}
+		before := *mfa
+		mfa.TotpSecret = secret
+		mfa.LastUsedStep = 0
+		if err := c.UserMfaRepository.Update(tx, mfa); err != nil {
+			c.Log.WithError(err).Error("error updating mfa enrollment")
+			return nil, fiber.ErrInternalServerError
+		}
+		if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionUpdate, &before, mfa); err != nil {
+			c.Log.WithError(err).Error("error writing audit log")
+			return nil, fiber.ErrInternalServerError
+		}
+	} else {
+		mfa = &entity.UserMfa{
+			UserId:     user.ID,
+			TotpSecret: secret,
+		}
+		if err := c.UserMfaRepository.Create(tx, mfa); err != nil {
+			c.Log.WithError(err).Error("error creating mfa enrollment")
+			return nil, fiber.ErrInternalServerError
+		}
+		if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionCreate, nil, mfa); err != nil {
+			c.Log.WithError(err).Error("error writing audit log")
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error enrolling mfa")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &model.MfaEnrollResponse{
+		Secret:     secret,
+		OtpauthUri: helper.TotpURI(c.Config.Issuer, user.Email, secret),
+	}, nil
+}
+
+func (c *MfaUseCase) Confirm(ctx context.Context, request *model.ConfirmMfaRequest) (*model.MfaRecoveryCodesResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.UserId); err != nil {
+		c.Log.WithError(err).Error("error getting user")
+		return nil, fiber.ErrNotFound
+	}
+
+	mfa := new(entity.UserMfa)
+	if err := c.UserMfaRepository.FindByUserId(tx, mfa, user.ID); err != nil {
+		c.Log.WithError(err).Error("error getting mfa enrollment")
+		return nil, fiber.ErrNotFound
+	}
+	if mfa.ConfirmedAt != nil {
+		return nil, fiber.NewError(fiber.StatusConflict, "mfa is already enabled")
+	}
+
+	now := time.Now()
+	if err := c.verifyCode(ctx, tx, user, mfa, request.Code, now); err != nil {
+		return nil, err
+	}
+
+	before := *mfa
+	mfa.ConfirmedAt = &now
+	if err := c.UserMfaRepository.Update(tx, mfa); err != nil {
+		c.Log.WithError(err).Error("error confirming mfa")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionUpdate, &before, mfa); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	codes, err := c.replaceRecoveryCodes(ctx, tx, user.ID)
+	if err != nil {
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error confirming mfa")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &model.MfaRecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (c *MfaUseCase) RegenerateRecoveryCodes(ctx context.Context, request *model.RegenerateRecoveryCodesRequest) (*model.MfaRecoveryCodesResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.UserId); err != nil {
+		c.Log.WithError(err).Error("error getting user")
+		return nil, fiber.ErrNotFound
+	}
+
+	mfa, err := c.confirmedMfa(ctx, tx, user, request.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := c.replaceRecoveryCodes(ctx, tx, mfa.UserId)
+	if err != nil {
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error regenerating recovery codes")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &model.MfaRecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (c *MfaUseCase) Disable(ctx context.Context, request *model.DisableMfaRequest) (bool, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return false, fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.UserId); err != nil {
+		c.Log.WithError(err).Error("error getting user")
+		return false, fiber.ErrNotFound
+	}
+
+	role := new(entity.Role)
+	if err := c.RoleRepository.FindById(tx, role, user.RoleId); err == nil && role.MfaRequired {
+		return false, fiber.NewError(fiber.StatusForbidden, "mfa is required for your role")
+	}
+
+	mfa, err := c.confirmedMfa(ctx, tx, user, request.Code)
+	if err != nil {
+		return false, err
+	}
+
+	if err := c.UserRecoveryCodeRepository.DeleteByUserId(tx, user.ID); err != nil {
+		c.Log.WithError(err).Error("error deleting recovery codes")
+		return false, fiber.ErrInternalServerError
+	}
+
+	if err := c.UserMfaRepository.Delete(tx, mfa); err != nil {
+		c.Log.WithError(err).Error("error disabling mfa")
+		return false, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionDelete, mfa, nil); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return false, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error disabling mfa")
+		return false, fiber.ErrInternalServerError
+	}
+
+	return true, nil
+}
+
+// confirmedMfa memuat enrollment yang sudah aktif dan memastikan kode TOTP benar
+func (c *MfaUseCase) confirmedMfa(ctx context.Context, tx *gorm.DB, user *entity.User, code string) (*entity.UserMfa, error) {
+	mfa := new(entity.UserMfa)
+	if err := c.UserMfaRepository.FindByUserId(tx, mfa, user.ID); err != nil || mfa.ConfirmedAt == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "mfa is not enabled")
+	}
+
+	if err := c.verifyCode(ctx, tx, user, mfa, code, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return mfa, nil
+}
+
+// verifyCode mengecek kode TOTP di bawah throttle akun yang sama dengan login,
+// sehingga endpoint MFA tidak bisa dipakai untuk menebak kode tanpa batas
+func (c *MfaUseCase) verifyCode(ctx context.Context, tx *gorm.DB, user *entity.User, mfa *entity.UserMfa, code string, now time.Time) error {
+	limits := []loginThrottleLimit{{Key: accountThrottleKey(user.Email), MaxAttempts: c.LoginThrottle.MaxAttempts}}
+	attempt, err := reserveLoginAttempt(c.DB.WithContext(ctx), c.Log, c.LoginThrottleRepository, c.LoginThrottle, limits, now)
+	if err != nil {
+		return err
+	}
+
+	ok, err := verifyTotp(tx, c.UserMfaRepository, mfa, code, now)
+	if err != nil {
+		c.Log.WithError(err).Error("error verifying totp code")
+		return fiber.ErrInternalServerError
+	}
+	if !ok {
+		recordLoginFailure(c.DB.WithContext(ctx), c.Log, c.LoginThrottleRepository, c.LoginThrottle, attempt)
+		return fiber.NewError(fiber.StatusBadRequest, "invalid mfa code")
+	}
+	releaseLoginAttempt(tx, c.Log, c.LoginThrottleRepository, attempt)
+
+	return nil
+}
+
+// replaceRecoveryCodes menghapus recovery code lama dan mengembalikan kode baru, hanya hash-nya yang disimpan
+func (c *MfaUseCase) replaceRecoveryCodes(ctx context.Context, tx *gorm.DB, userId string) ([]string, error) {
+	if err := c.UserRecoveryCodeRepository.DeleteByUserId(tx, userId); err != nil {
+		c.Log.WithError(err).Error("error deleting recovery codes")
+		return nil, err
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		code, err := helper.GenerateRecoveryCode()
+		if err != nil {
+			c.Log.WithError(err).Error("error generating recovery code")
+			return nil, err
+		}
+
+		recoveryCode := &entity.UserRecoveryCode{
+			ID:       uuid.New().String(),
+			UserId:   userId,
+			CodeHash: helper.HashToken(helper.NormalizeRecoveryCode(code)),
+		}
+		if err := c.UserRecoveryCodeRepository.Create(tx, recoveryCode); err != nil {
+			c.Log.WithError(err).Error("error creating recovery code")
+			return nil, err
+		}
+		if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionCreate, nil, recoveryCode); err != nil {
+			c.Log.WithError(err).Error("error writing audit log")
+			return nil, err
+		}
+		codes[i] = code
+	}
+
+	return codes, nil
+}
@@ -0,0 +1,465 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+	"todo-app/internal/entity"
+	"todo-app/internal/gateway/messaging"
+	"todo-app/internal/gateway/oidc"
+	"todo-app/internal/model"
+	"todo-app/internal/model/converter"
+	"todo-app/internal/repository"
+	"todo-app/internal/util/helper"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// OidcGroupMapping maps one identity provider group to a local role or department id
+type OidcGroupMapping struct {
+	Group string
+	Id    string
+}
+
+// OidcConfig holds the group mapping and login rules, loaded from viper in config.NewOidcConfig
+type OidcConfig struct {
+	RoleMapping          []OidcGroupMapping
+	DepartmentMapping    []OidcGroupMapping
+	DefaultRoleId        string
+	DefaultDepartmentId  string
+	RequireVerifiedEmail bool
+	StateTTL             time.Duration
+}
+
+var errOidcNotConfigured = fiber.NewError(fiber.StatusNotFound, "oidc login is not configured")
+
+type OidcUseCase struct {
+	DB                        *gorm.DB
+	Log                       *logrus.Logger
+	Validate                  *validator.Validate
+	Provider                  *oidc.Provider
+	Config                    *OidcConfig
+	UserUseCase               *UserUseCase
+	UserRepository            *repository.UserRepository
+	UserIdentityRepository    *repository.UserIdentityRepository
+	OidcLoginStateRepository  *repository.OidcLoginStateRepository
+	RoleRepository            *repository.RoleRepository
+	DepartmentRepository      *repository.DepartmentRepository
+	UserMfaRepository         *repository.UserMfaRepository
+	UserSessionRepository     *repository.UserSessionRepository
+	RefreshTokenRepository    *repository.RefreshTokenRepository
+	TokenRevocationRepository *repository.TokenRevocationRepository
+	AuditLogRepository        *repository.AuditLogRepository
+	OutboxEventRepository     *repository.OutboxEventRepository
+}
+
+func NewOidcUseCase(db *gorm.DB, logger *logrus.Logger, validate *validator.Validate, provider *oidc.Provider, config *OidcConfig,
+	userUseCase *UserUseCase, userRepository *repository.UserRepository, userIdentityRepository *repository.UserIdentityRepository,
+	oidcLoginStateRepository *repository.OidcLoginStateRepository, roleRepository *repository.RoleRepository,
+	departmentRepository *repository.DepartmentRepository, userMfaRepository *repository.UserMfaRepository,
+	userSessionRepository *repository.UserSessionRepository, refreshTokenRepository *repository.RefreshTokenRepository,
+	tokenRevocationRepository *repository.TokenRevocationRepository, auditLogRepository *repository.AuditLogRepository,
+	outboxEventRepository *repository.OutboxEventRepository) *OidcUseCase {
+	return &OidcUseCase{
+		DB:                        db,
+		Log:                       logger,
+		Validate:                  validate,
+		Provider:                  provider,
+		Config:                    config,
+		UserUseCase:               userUseCase,
+		UserRepository:            userRepository,
+		UserIdentityRepository:    userIdentityRepository,
+		OidcLoginStateRepository:  oidcLoginStateRepository,
+		RoleRepository:            roleRepository,
+		DepartmentRepository:      departmentRepository,
+		UserMfaRepository:         userMfaRepository,
+		UserSessionRepository:     userSessionRepository,
+		RefreshTokenRepository:    refreshTokenRepository,
+		TokenRevocationRepository: tokenRevocationRepository,
+		AuditLogRepository:        auditLogRepository,
+		OutboxEventRepository:     outboxEventRepository,
+	}
+}
+
+// Authorize starts a login: it stores the PKCE verifier and nonce under a random state and returns the provider URL
+func (c *OidcUseCase) Authorize(ctx context.Context) (*model.OidcAuthorizeResponse, error) {
+	if c.Provider == nil {
+		return nil, errOidcNotConfigured
+	}
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	state, err := helper.GenerateOpaqueToken()
+	if err != nil {
+		c.Log.Warnf("Failed generate oidc state : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	nonce, err := helper.GenerateOpaqueToken()
+	if err != nil {
+		c.Log.Warnf("Failed generate oidc nonce : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	codeVerifier, err := helper.GenerateOpaqueToken()
+	if err != nil {
+		c.Log.Warnf("Failed generate pkce code verifier : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	authorizationUrl, err := c.Provider.AuthCodeURL(ctx, state, nonce, codeVerifier)
+	if err != nil {
+		c.Log.Warnf("Failed build oidc authorization url : %+v", err)
+		return nil, fiber.ErrBadGateway
+	}
+
+	now := time.Now()
+
+	// State yang tidak pernah diselesaikan dibersihkan sambil jalan
+	if err := c.OidcLoginStateRepository.DeleteExpired(tx, now); err != nil {
+		c.Log.Warnf("Failed delete expired oidc states : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	loginState := &entity.OidcLoginState{
+		ID:           uuid.New().String(),
+		StateHash:    helper.HashToken(state),
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+		ExpiresAt:    now.Add(c.Config.StateTTL),
+	}
+	if err := c.OidcLoginStateRepository.Create(tx, loginState); err != nil {
+		c.Log.Warnf("Failed create oidc state : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &model.OidcAuthorizeResponse{
+		AuthorizationUrl: authorizationUrl,
+		State:            state,
+		ExpiresIn:        int64(c.Config.StateTTL.Seconds()),
+	}, nil
+}
+
+// Callback redeems the authorization code, then signs in the linked user, links an existing user by email
+// or creates a new one. Roles and departments follow the identity provider groups on every login.
+func (c *OidcUseCase) Callback(ctx context.Context, request *model.OidcCallbackRequest) (*model.UserResponse, error) {
+	if c.Provider == nil {
+		return nil, errOidcNotConfigured
+	}
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body : %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	now := time.Now()
+
+	// State dipakai habis di transaksi terpisah supaya request ke identity provider tidak menahan lock database
+	loginState, err := c.consumeState(ctx, request.State, now)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := c.Provider.Exchange(ctx, request.Code, loginState.CodeVerifier)
+	if err != nil {
+		c.Log.Warnf("Failed exchange oidc code : %+v", err)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	idToken, err := c.Provider.VerifyIDToken(ctx, token.IdToken, loginState.Nonce)
+	if err != nil {
+		c.Log.Warnf("Failed verify oidc id token : %+v", err)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	user, previous, err := c.resolveUser(ctx, tx, idToken, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.recordUserEvents(ctx, tx, previous, user); err != nil {
+		c.Log.Warnf("Failed write user event : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if !user.IsActive {
+		c.Log.Warnf("Oidc login rejected, user %s is not active", user.ID)
+		return nil, fiber.NewError(fiber.StatusForbidden, "user is not active")
+	}
+
+	// MFA lokal tetap berlaku sama seperti login dengan password
+	mfaEnabled, err := c.UserMfaRepository.IsConfirmed(tx, user.ID)
+	if err != nil {
+		c.Log.Warnf("Failed check mfa enrollment : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if mfaEnabled {
+		mfaToken, err := c.UserUseCase.issueUserToken(tx, user.ID, entity.UserTokenPurposeMfaChallenge, c.UserUseCase.Mfa.ChallengeTTL)
+		if err != nil {
+			return nil, fiber.ErrInternalServerError
+		}
+
+		if err := tx.Commit().Error; err != nil {
+			c.Log.Warnf("Failed commit transaction : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+
+		return converter.UserToMfaChallengeResponse(mfaToken, int64(c.UserUseCase.Mfa.ChallengeTTL.Seconds())), nil
+	}
+
+	pending, err := mfaPending(tx, c.RoleRepository, c.UserMfaRepository, user)
+	if err != nil {
+		c.Log.Warnf("Failed check mfa requirement : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response, err := c.UserUseCase.startSession(ctx, tx, user, request.DeviceLabel, request.IpAddress, request.UserAgent, pending, now)
+	if err != nil {
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return response, nil
+}
+
+// recordUserEvents menulis event ke outbox dalam transaksi login. Previous nil berarti user baru dibuat dari login ini.
+func (c *OidcUseCase) recordUserEvents(ctx context.Context, tx *gorm.DB, previous *entity.User, user *entity.User) error {
+	if previous == nil {
+		event := converter.UserToRegisteredEvent(user, model.UserRegistrationSourceOidc)
+		return recordEvent(ctx, tx, c.OutboxEventRepository, messaging.TopicUserRegistered, event)
+	}
+
+	if previous.RoleId != user.RoleId {
+		event := converter.UserToRoleChangedEvent(user, previous.RoleId, "")
+		return recordEvent(ctx, tx, c.OutboxEventRepository, messaging.TopicRoleChanged, event)
+	}
+	return nil
+}
+
+func (c *OidcUseCase) consumeState(ctx context.Context, state string, now time.Time) (*entity.OidcLoginState, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	loginState := new(entity.OidcLoginState)
+	if err := c.OidcLoginStateRepository.FindByStateHash(tx, loginState, helper.HashToken(state)); err != nil {
+		c.Log.Warnf("Failed find oidc state : %+v", err)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	if loginState.UsedAt != nil || now.After(loginState.ExpiresAt) {
+		c.Log.Warnf("Oidc state %s is used or expired", loginState.ID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	loginState.UsedAt = &now
+	if err := c.OidcLoginStateRepository.Update(tx, loginState); err != nil {
+		c.Log.Warnf("Failed mark oidc state used : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return loginState, nil
+}
+
+// resolveUser mencari user lewat identity (issuer + subject), lalu lewat email, dan terakhir membuat user baru.
+// Selain user, dikembalikan juga salinan user sebelum sinkronisasi group, atau nil kalau user baru dibuat.
+func (c *OidcUseCase) resolveUser(ctx context.Context, tx *gorm.DB, idToken *oidc.IDToken, now time.Time) (*entity.User, *entity.User, error) {
+	roleId := mapGroups(idToken.Groups, c.Config.RoleMapping)
+	departmentId := mapGroups(idToken.Groups, c.Config.DepartmentMapping)
+
+	user := new(entity.User)
+	identity := new(entity.UserIdentity)
+	linked := false
+	created := false
+	err := c.UserIdentityRepository.FindByIssuerAndSubject(tx, identity, idToken.Issuer, idToken.Subject)
+	switch {
+	case err == nil:
+		if err := c.UserRepository.FindById(tx, user, identity.UserId); err != nil {
+			c.Log.Warnf("Failed find user of oidc identity %s : %+v", identity.ID, err)
+			return nil, nil, fiber.NewError(fiber.StatusForbidden, "user is not active")
+		}
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		// Link berdasarkan email hanya aman kalau identity provider sudah memverifikasi email tersebut
+		if idToken.Email == "" || (c.Config.RequireVerifiedEmail && !idToken.EmailVerified) {
+			c.Log.Warnf("Oidc login rejected, email of subject %s is missing or not verified", idToken.Subject)
+			return nil, nil, fiber.NewError(fiber.StatusForbidden, "email is not verified by the identity provider")
+		}
+
+		if err := c.UserRepository.FindByEmail(tx, user, idToken.Email); err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				c.Log.Warnf("Failed find user by email : %+v", err)
+				return nil, nil, fiber.ErrInternalServerError
+			}
+			user, err = c.createUser(ctx, tx, idToken, roleId, departmentId)
+			if err != nil {
+				return nil, nil, err
+			}
+			created = true
+		}
+
+		identity = &entity.UserIdentity{
+			ID:      uuid.New().String(),
+			UserId:  user.ID,
+			Issuer:  idToken.Issuer,
+			Subject: idToken.Subject,
+		}
+		linked = true
+	default:
+		c.Log.Warnf("Failed find oidc identity : %+v", err)
+		return nil, nil, fiber.ErrInternalServerError
+	}
+
+	var previous *entity.User
+	if !created {
+		snapshot := *user
+		previous = &snapshot
+	}
+
+	if err := c.syncGroups(ctx, tx, user, roleId, departmentId, now); err != nil {
+		return nil, nil, err
+	}
+
+	if idToken.Email != "" {
+		identity.Email = idToken.Email
+	}
+	identity.LastLoginAt = &now
+	if linked {
+		err = c.UserIdentityRepository.Create(tx, identity)
+	} else {
+		err = c.UserIdentityRepository.Update(tx, identity)
+	}
+	if err != nil {
+		c.Log.Warnf("Failed save oidc identity : %+v", err)
+		return nil, nil, fiber.ErrInternalServerError
+	}
+
+	// Update last_login_at sudah tercatat lewat audit login, yang dicatat di sini hanya identity yang baru di-link
+	if linked {
+		if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionCreate, nil, identity); err != nil {
+			c.Log.Warnf("Failed write audit log : %+v", err)
+			return nil, nil, fiber.ErrInternalServerError
+		}
+	}
+
+	return user, previous, nil
+}
+
+func (c *OidcUseCase) createUser(ctx context.Context, tx *gorm.DB, idToken *oidc.IDToken, roleId string, departmentId string) (*entity.User, error) {
+	if roleId == "" {
+		roleId = c.Config.DefaultRoleId
+	}
+	if departmentId == "" {
+		departmentId = c.Config.DefaultDepartmentId
+	}
+	if roleId == "" || departmentId == "" {
+		c.Log.Warnf("Oidc login rejected, no role or department mapped for groups %v", idToken.Groups)
+		return nil, fiber.NewError(fiber.StatusForbidden, "no role or department is mapped for this account")
+	}
+
+	name := strings.TrimSpace(idToken.Name)
+	if name == "" {
+		name = idToken.Email
+	}
+	if len(name) > 100 {
+		name = name[:100]
+	}
+
+	// Password dikosongkan, user SSO tidak bisa login dengan password karena bcrypt tidak pernah cocok dengan string kosong
+	user := &entity.User{
+		ID:            uuid.New().String(),
+		Email:         idToken.Email,
+		Name:          name,
+		RoleId:        roleId,
+		DepartementId: departmentId,
+		IsActive:      true,
+	}
+	if err := c.UserRepository.Create(tx, user); err != nil {
+		c.Log.Warnf("Failed create oidc user : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionCreate, nil, user); err != nil {
+		c.Log.Warnf("Failed write audit log : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return user, nil
+}
+
+// syncGroups menerapkan role dan department dari group identity provider.
+// Kalau berubah, semua session lama dicabut supaya token dengan role lama tidak berlaku lagi.
+func (c *OidcUseCase) syncGroups(ctx context.Context, tx *gorm.DB, user *entity.User, roleId string, departmentId string, now time.Time) error {
+	before := *user
+	changed := false
+	if roleId != "" && roleId != user.RoleId {
+		user.RoleId = roleId
+		changed = true
+	}
+	if departmentId != "" && departmentId != user.DepartementId {
+		user.DepartementId = departmentId
+		changed = true
+	}
+
+	role := new(entity.Role)
+	if err := c.RoleRepository.FindById(tx, role, user.RoleId); err != nil {
+		c.Log.Warnf("Failed find mapped role %s : %+v", user.RoleId, err)
+		return fiber.ErrInternalServerError
+	}
+	department := new(entity.Department)
+	if err := c.DepartmentRepository.FindById(tx, department, user.DepartementId); err != nil {
+		c.Log.Warnf("Failed find mapped department %s : %+v", user.DepartementId, err)
+		return fiber.ErrInternalServerError
+	}
+
+	if !changed {
+		return nil
+	}
+
+	if err := c.UserRepository.Update(tx, user); err != nil {
+		c.Log.Warnf("Failed update user groups : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionUpdate, &before, user); err != nil {
+		c.Log.Warnf("Failed write audit log : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := revokeAllSessions(ctx, tx, c.UserSessionRepository, c.RefreshTokenRepository, c.TokenRevocationRepository, c.AuditLogRepository, user.ID, c.UserUseCase.JWTSigner.AccessTokenTTL, now); err != nil {
+		c.Log.Warnf("Failed revoke user sessions : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// mapGroups mengembalikan id dari mapping pertama (sesuai urutan konfigurasi) yang group-nya dimiliki user
+func mapGroups(groups []string, mappings []OidcGroupMapping) string {
+	for _, mapping := range mappings {
+		if slices.Contains(groups, mapping.Group) {
+			return mapping.Id
+		}
+	}
+	return ""
+}
@@ -0,0 +1,309 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"todo-app/internal/entity"
+	"todo-app/internal/gateway/messaging"
+	"todo-app/internal/gateway/oidc"
+	"todo-app/internal/gateway/oidc/oidctest"
+	"todo-app/internal/model"
+	"todo-app/internal/repository"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+)
+
+const (
+	oidcClientId    = "todo-app"
+	memberRoleId    = "role-member"
+	adminRoleId     = "role-admin"
+	defaultDeptId   = "department-default"
+	engineeringId   = "department-engineering"
+	oidcTestSubject = "subject-1"
+)
+
+type oidcTest struct {
+	db      *gorm.DB
+	fake    *oidctest.Provider
+	useCase *OidcUseCase
+}
+
+func newOidcTest(t *testing.T) *oidcTest {
+	t.Helper()
+	db := newTestDB(t)
+	log := newTestLogger()
+
+	db.Create(&[]entity.Role{{ID: memberRoleId, Name: "member"}, {ID: adminRoleId, Name: "admin"}})
+	db.Create(&[]entity.Department{{ID: defaultDeptId, Name: "default"}, {ID: engineeringId, Name: "engineering"}})
+
+	fake := oidctest.NewProvider(oidcClientId)
+	t.Cleanup(fake.Close)
+	provider := oidc.NewProvider(fake.Issuer(), oidcClientId, "secret", "http://localhost:3000/callback",
+		[]string{"openid", "email", "profile"}, "groups", log)
+
+	userUseCase := newTestUserUseCase(t, db, log)
+
+	config := &OidcConfig{
+		RoleMapping:          []OidcGroupMapping{{Group: "admins", Id: adminRoleId}},
+		DepartmentMapping:    []OidcGroupMapping{{Group: "engineering", Id: engineeringId}},
+		DefaultRoleId:        memberRoleId,
+		DefaultDepartmentId:  defaultDeptId,
+		RequireVerifiedEmail: true,
+		StateTTL:             10 * time.Minute,
+	}
+
+	useCase := NewOidcUseCase(db, log, validator.New(), provider, config, userUseCase, userUseCase.UserRepository,
+		repository.NewUserIdentityRepository(log), repository.NewOidcLoginStateRepository(log), userUseCase.RoleRepository,
+		userUseCase.DepartmentRepository, userUseCase.UserMfaRepository, userUseCase.UserSessionRepository,
+		userUseCase.RefreshTokenRepository, userUseCase.TokenRevocationRepository, userUseCase.AuditLogRepository,
+		userUseCase.OutboxEventRepository)
+
+	return &oidcTest{db: db, fake: fake, useCase: useCase}
+}
+
+// login menjalankan Authorize, login di identity provider dengan claims, lalu Callback dengan code dan state dari redirect
+func (s *oidcTest) login(t *testing.T, claims jwt.MapClaims) (*model.UserResponse, error) {
+	t.Helper()
+	ctx := context.Background()
+
+	authorize, err := s.useCase.Authorize(ctx)
+	if err != nil {
+		t.Fatalf("Authorize returned %v", err)
+	}
+	code, state, err := s.fake.Authorize(authorize.AuthorizationUrl, claims)
+	if err != nil {
+		t.Fatalf("identity provider rejected the authorization request: %v", err)
+	}
+	if state != authorize.State {
+		t.Fatalf("redirect state %q does not match %q", state, authorize.State)
+	}
+
+	return s.useCase.Callback(ctx, &model.OidcCallbackRequest{Code: code, State: state})
+}
+
+func (s *oidcTest) findUser(t *testing.T, email string) *entity.User {
+	t.Helper()
+	user := new(entity.User)
+	if err := s.db.Where("email = ?", email).Take(user).Error; err != nil {
+		t.Fatalf("find user %s: %v", email, err)
+	}
+	return user
+}
+
+func (s *oidcTest) countUsers(t *testing.T) int64 {
+	t.Helper()
+	var total int64
+	s.db.Model(new(entity.User)).Count(&total)
+	return total
+}
+
+func (s *oidcTest) outboxTopics(t *testing.T) []string {
+	t.Helper()
+	var topics []string
+	s.db.Model(new(entity.OutboxEvent)).Order("id").Pluck("topic", &topics)
+	return topics
+}
+
+func userClaims(email string, groups ...string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":            oidcTestSubject,
+		"email":          email,
+		"email_verified": true,
+		"name":           "Jane Doe",
+		"groups":         groups,
+	}
+}
+
+func assertStatus(t *testing.T, err error, status int) {
+	t.Helper()
+	var fiberErr *fiber.Error
+	if !errors.As(err, &fiberErr) || fiberErr.Code != status {
+		t.Fatalf("error is %v, want status %d", err, status)
+	}
+}
+
+func TestOidcCallbackCreatesUserOnFirstLogin(t *testing.T) {
+	s := newOidcTest(t)
+
+	response, err := s.login(t, userClaims("jane@example.com"))
+	if err != nil {
+		t.Fatalf("Callback returned %v", err)
+	}
+	if response.Token == "" || response.RefreshToken == "" {
+		t.Fatalf("login did not start a session: %+v", response)
+	}
+
+	user := s.findUser(t, "jane@example.com")
+	if user.RoleId != memberRoleId || user.DepartementId != defaultDeptId || !user.IsActive || user.Name != "Jane Doe" {
+		t.Fatalf("unexpected user %+v", user)
+	}
+
+	identity := new(entity.UserIdentity)
+	if err := s.db.Where("issuer = ? AND subject = ?", s.fake.Issuer(), oidcTestSubject).Take(identity).Error; err != nil {
+		t.Fatalf("identity is not linked: %v", err)
+	}
+	if identity.UserId != user.ID || identity.LastLoginAt == nil {
+		t.Fatalf("unexpected identity %+v", identity)
+	}
+
+	if topics := s.outboxTopics(t); len(topics) != 1 || topics[0] != messaging.TopicUserRegistered {
+		t.Fatalf("outbox topics are %v, want only %s", topics, messaging.TopicUserRegistered)
+	}
+
+	// Login berikutnya memakai identity yang sama, bukan membuat user baru
+	if _, err := s.login(t, userClaims("jane@example.com")); err != nil {
+		t.Fatalf("second Callback returned %v", err)
+	}
+	if total := s.countUsers(t); total != 1 {
+		t.Fatalf("%d users after the second login, want 1", total)
+	}
+	if topics := s.outboxTopics(t); len(topics) != 1 {
+		t.Fatalf("second login wrote outbox events %v", topics)
+	}
+}
+
+func TestOidcCallbackRejectsReusedOrUnknownState(t *testing.T) {
+	s := newOidcTest(t)
+	ctx := context.Background()
+
+	authorize, err := s.useCase.Authorize(ctx)
+	if err != nil {
+		t.Fatalf("Authorize returned %v", err)
+	}
+	code, state, err := s.fake.Authorize(authorize.AuthorizationUrl, userClaims("jane@example.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.useCase.Callback(ctx, &model.OidcCallbackRequest{Code: code, State: "unknown-state"})
+	assertStatus(t, err, fiber.StatusUnauthorized)
+
+	if _, err := s.useCase.Callback(ctx, &model.OidcCallbackRequest{Code: code, State: state}); err != nil {
+		t.Fatalf("Callback returned %v", err)
+	}
+
+	_, err = s.useCase.Callback(ctx, &model.OidcCallbackRequest{Code: code, State: state})
+	assertStatus(t, err, fiber.StatusUnauthorized)
+
+	// State yang tidak valid ditolak sebelum code ditukar ke identity provider
+	if s.fake.TokenRequests != 1 {
+		t.Fatalf("token endpoint was called %d times, want 1", s.fake.TokenRequests)
+	}
+}
+
+func TestOidcCallbackLinksExistingUserByVerifiedEmail(t *testing.T) {
+	s := newOidcTest(t)
+	existing := &entity.User{ID: "user-1", Email: "jane@example.com", Name: "Jane", RoleId: memberRoleId,
+		DepartementId: defaultDeptId, IsActive: true}
+	if err := s.db.Create(existing).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.login(t, userClaims("jane@example.com")); err != nil {
+		t.Fatalf("Callback returned %v", err)
+	}
+
+	if total := s.countUsers(t); total != 1 {
+		t.Fatalf("%d users after linking, want 1", total)
+	}
+	identity := new(entity.UserIdentity)
+	if err := s.db.Where("subject = ?", oidcTestSubject).Take(identity).Error; err != nil || identity.UserId != existing.ID {
+		t.Fatalf("identity %+v is not linked to the existing user: %v", identity, err)
+	}
+	if topics := s.outboxTopics(t); len(topics) != 0 {
+		t.Fatalf("linking wrote outbox events %v", topics)
+	}
+}
+
+func TestOidcCallbackRejectsUnverifiedEmail(t *testing.T) {
+	s := newOidcTest(t)
+	existing := &entity.User{ID: "user-1", Email: "jane@example.com", Name: "Jane", RoleId: memberRoleId,
+		DepartementId: defaultDeptId, IsActive: true}
+	if err := s.db.Create(existing).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	claims := userClaims("jane@example.com")
+	claims["email_verified"] = false
+	_, err := s.login(t, claims)
+	assertStatus(t, err, fiber.StatusForbidden)
+
+	var identities int64
+	s.db.Model(new(entity.UserIdentity)).Count(&identities)
+	if identities != 0 {
+		t.Fatal("unverified email was linked to the existing user")
+	}
+}
+
+func TestOidcCallbackMapsGroupsToRoleAndDepartment(t *testing.T) {
+	s := newOidcTest(t)
+
+	first, err := s.login(t, userClaims("jane@example.com", "engineering"))
+	if err != nil {
+		t.Fatalf("Callback returned %v", err)
+	}
+	user := s.findUser(t, "jane@example.com")
+	if user.RoleId != memberRoleId || user.DepartementId != engineeringId {
+		t.Fatalf("user has role %s and department %s after the first login", user.RoleId, user.DepartementId)
+	}
+
+	// Group admins ditambahkan di identity provider, login berikutnya mengubah role dan mencabut session lama
+	second, err := s.login(t, userClaims("jane@example.com", "engineering", "admins"))
+	if err != nil {
+		t.Fatalf("Callback returned %v", err)
+	}
+	user = s.findUser(t, "jane@example.com")
+	if user.RoleId != adminRoleId || user.DepartementId != engineeringId {
+		t.Fatalf("user has role %s and department %s after the group change", user.RoleId, user.DepartementId)
+	}
+
+	topics := s.outboxTopics(t)
+	if len(topics) != 2 || topics[1] != messaging.TopicRoleChanged {
+		t.Fatalf("outbox topics are %v, want %s after %s", topics, messaging.TopicRoleChanged, messaging.TopicUserRegistered)
+	}
+
+	var sessions []entity.UserSession
+	s.db.Where("user_id = ?", user.ID).Order("created_at").Find(&sessions)
+	if len(sessions) != 2 || sessions[0].RevokedAt == nil || sessions[1].RevokedAt != nil {
+		t.Fatalf("want the first session revoked and the second active, got %+v", sessions)
+	}
+
+	// Token baru diterbitkan di detik yang sama dengan pencabutan, hanya token lama yang ditolak
+	ctx := context.Background()
+	_, err = s.useCase.UserUseCase.Verify(ctx, &model.VerifyUserRequest{Token: first.Token})
+	assertStatus(t, err, fiber.StatusUnauthorized)
+	if _, err := s.useCase.UserUseCase.Verify(ctx, &model.VerifyUserRequest{Token: second.Token}); err != nil {
+		t.Fatalf("token issued after the group change was rejected: %v", err)
+	}
+}
+
+func TestOidcCallbackRejectsInvalidIDToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims jwt.MapClaims
+	}{
+		{name: "nonce mismatch", claims: jwt.MapClaims{"nonce": "another-nonce"}},
+		{name: "audience mismatch", claims: jwt.MapClaims{"aud": "another-client"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newOidcTest(t)
+			claims := userClaims("jane@example.com")
+			for key, value := range tt.claims {
+				claims[key] = value
+			}
+
+			_, err := s.login(t, claims)
+			assertStatus(t, err, fiber.StatusUnauthorized)
+			if total := s.countUsers(t); total != 0 {
+				t.Fatalf("%d users created from an invalid id token", total)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"todo-app/internal/entity"
+	"todo-app/internal/gateway/messaging"
+	"todo-app/internal/model"
+	"todo-app/internal/repository"
+	"todo-app/internal/util/helper"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// recordEvent writes the event to the outbox in the same transaction as the change, so an event is only
+// published when the change is committed. The outbox relay in the worker sends it to the topic afterwards.
+// The request id becomes the correlation id of the event, so consumers can be traced back to the request.
+// A topic without a producer in the relay is rejected, its event would stay pending forever.
+func recordEvent(ctx context.Context, tx *gorm.DB, outboxEventRepository *repository.OutboxEventRepository, topic string, event model.Event) error {
+	if !slices.Contains(messaging.Topics, topic) {
+		return fmt.Errorf("unknown outbox topic %s", topic)
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	outboxEvent := &entity.OutboxEvent{
+		EventId:      uuid.NewString(),
+		EventType:    event.GetEventType(),
+		EventVersion: event.GetEventVersion(),
+		Topic:        topic,
+		EventKey:     event.GetId(),
+		Payload:      string(payload),
+	}
+	if requestId := helper.GetAuditContext(ctx).RequestId; requestId != "" {
+		outboxEvent.CorrelationId = &requestId
+	}
+
+	return outboxEventRepository.Create(tx, outboxEvent)
+}
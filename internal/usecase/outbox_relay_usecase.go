@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"todo-app/internal/gateway/messaging"
+	"todo-app/internal/model/converter"
+	"todo-app/internal/repository"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// OutboxConfig holds the relay settings, loaded from viper in config.NewOutboxConfig
+type OutboxConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	Retention    time.Duration
+	// ProcessedRetention harus lebih lama dari retensi topic Kafka supaya event yang dikirim ulang tetap dikenali
+	ProcessedRetention time.Duration
+}
+
+type OutboxRelayUseCase struct {
+	DB                    *gorm.DB
+	Log                   *logrus.Logger
+	Config                *OutboxConfig
+	OutboxEventRepository *repository.OutboxEventRepository
+	// ProcessedEventRepository dipakai untuk membersihkan catatan deduplikasi consumer
+	ProcessedEventRepository *repository.ProcessedEventRepository
+	Senders                  map[string]messaging.EnvelopeSender
+}
+
+func NewOutboxRelayUseCase(db *gorm.DB, logger *logrus.Logger, config *OutboxConfig,
+	outboxEventRepository *repository.OutboxEventRepository, processedEventRepository *repository.ProcessedEventRepository,
+	senders ...messaging.EnvelopeSender) *OutboxRelayUseCase {
+	senderByTopic := make(map[string]messaging.EnvelopeSender, len(senders))
+	for _, sender := range senders {
+		senderByTopic[*sender.GetTopic()] = sender
+	}
+
+	return &OutboxRelayUseCase{
+		DB:                       db,
+		Log:                      logger,
+		Config:                   config,
+		OutboxEventRepository:    outboxEventRepository,
+		ProcessedEventRepository: processedEventRepository,
+		Senders:                  senderByTopic,
+	}
+}
+
+// Relay publishes one batch of pending events in id order and returns how many were published.
+// The relay lock keeps a second worker from publishing at the same time. When an event fails, the later
+// events of the same topic wait for the next batch so they cannot overtake it.
+// An event is published at least once: if the worker stops before the commit, the batch is sent again.
+func (c *OutboxRelayUseCase) Relay(ctx context.Context) (int, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	locked, err := c.OutboxEventRepository.TryLockRelay(tx)
+	if err != nil {
+		c.Log.WithError(err).Error("error locking outbox relay")
+		return 0, err
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	outboxEvents, err := c.OutboxEventRepository.FindPending(tx, c.Config.BatchSize)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting pending outbox events")
+		return 0, err
+	}
+
+	published := make([]int64, 0, len(outboxEvents))
+	failedTopics := make(map[string]bool)
+	for _, outboxEvent := range outboxEvents {
+		if failedTopics[outboxEvent.Topic] {
+			continue
+		}
+
+		sender, ok := c.Senders[outboxEvent.Topic]
+		if !ok {
+			err = fmt.Errorf("no producer for topic %s", outboxEvent.Topic)
+		} else {
+			err = sender.SendEnvelope(converter.OutboxEventToEnvelope(&outboxEvent), []byte(outboxEvent.Payload))
+		}
+
+		if err != nil {
+			c.Log.WithError(err).Errorf("error publishing outbox event %d", outboxEvent.ID)
+			failedTopics[outboxEvent.Topic] = true
+			if err := c.OutboxEventRepository.MarkFailed(tx, outboxEvent.ID, err.Error()); err != nil {
+				c.Log.WithError(err).Error("error marking outbox event as failed")
+				return 0, err
+			}
+			continue
+		}
+		published = append(published, outboxEvent.ID)
+	}
+
+	if err := c.OutboxEventRepository.MarkPublished(tx, published, time.Now()); err != nil {
+		c.Log.WithError(err).Error("error marking outbox events as published")
+		return 0, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error committing outbox relay")
+		return 0, err
+	}
+
+	return len(published), nil
+}
+
+// Purge removes published events older than the retention
+func (c *OutboxRelayUseCase) Purge(ctx context.Context) (int64, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	total, err := c.OutboxEventRepository.DeletePublishedBefore(tx, time.Now().Add(-c.Config.Retention))
+	if err != nil {
+		c.Log.WithError(err).Error("error purging outbox events")
+		return 0, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error committing outbox purge")
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// PurgeProcessed removes the dedup records of consumed events older than the processed retention
+func (c *OutboxRelayUseCase) PurgeProcessed(ctx context.Context) (int64, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	total, err := c.ProcessedEventRepository.DeleteProcessedBefore(tx, time.Now().Add(-c.Config.ProcessedRetention))
+	if err != nil {
+		c.Log.WithError(err).Error("error purging processed events")
+		return 0, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error committing processed event purge")
+		return 0, err
+	}
+
+	return total, nil
+}
@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"todo-app/internal/entity"
+	"todo-app/internal/gateway/messaging"
+	"todo-app/internal/model"
+	"todo-app/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// fakeSender mencatat event id yang dikirim ke topic-nya, event id di failing gagal dikirim
+type fakeSender struct {
+	topic   string
+	sent    *[]string
+	failing map[string]bool
+	// afterSend dipanggil setelah setiap pengiriman yang berhasil
+	afterSend func()
+}
+
+func (s *fakeSender) GetTopic() *string {
+	return &s.topic
+}
+
+func (s *fakeSender) SendEnvelope(envelope *model.EventEnvelope, data []byte) error {
+	if s.failing[envelope.ID] {
+		return errors.New("broker is not available")
+	}
+	*s.sent = append(*s.sent, envelope.ID)
+	if s.afterSend != nil {
+		s.afterSend()
+	}
+	return nil
+}
+
+func newOutboxRelayTest(t *testing.T, senders ...messaging.EnvelopeSender) (*gorm.DB, *OutboxRelayUseCase) {
+	t.Helper()
+	db := newTestDB(t)
+	log := newTestLogger()
+	config := &OutboxConfig{PollInterval: time.Second, BatchSize: 10, Retention: time.Hour, ProcessedRetention: time.Hour}
+	return db, NewOutboxRelayUseCase(db, log, config, repository.NewOutboxEventRepository(log),
+		repository.NewProcessedEventRepository(log), senders...)
+}
+
+// outboxEntry adalah event yang ditulis langsung ke outbox, event id dipakai untuk mengecek urutan pengiriman
+type outboxEntry struct {
+	topic   string
+	eventId string
+}
+
+func recordEvents(t *testing.T, db *gorm.DB, entries ...outboxEntry) {
+	t.Helper()
+	outboxEventRepository := repository.NewOutboxEventRepository(newTestLogger())
+	for _, entry := range entries {
+		outboxEvent := &entity.OutboxEvent{
+			EventId:      entry.eventId,
+			EventType:    entry.topic,
+			EventVersion: 1,
+			Topic:        entry.topic,
+			EventKey:     entry.eventId,
+			Payload:      "{}",
+		}
+		if err := outboxEventRepository.Create(db, outboxEvent); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func pendingEventIds(t *testing.T, db *gorm.DB) []string {
+	t.Helper()
+	var ids []string
+	db.Model(new(entity.OutboxEvent)).Where("published_at IS NULL").Order("id").Pluck("event_id", &ids)
+	return ids
+}
+
+func TestRecordEventRejectsUnknownTopic(t *testing.T) {
+	db := newTestDB(t)
+	outboxEventRepository := repository.NewOutboxEventRepository(newTestLogger())
+
+	err := recordEvent(context.Background(), db, outboxEventRepository, "card.archived", &model.CardMovedEvent{ID: "card-1"})
+	if err == nil {
+		t.Fatal("recordEvent accepted a topic without a producer")
+	}
+	if err := recordEvent(context.Background(), db, outboxEventRepository, messaging.TopicCardMoved, &model.CardMovedEvent{ID: "card-1"}); err != nil {
+		t.Fatalf("recordEvent returned %v", err)
+	}
+
+	var total int64
+	db.Model(new(entity.OutboxEvent)).Count(&total)
+	if total != 1 {
+		t.Fatalf("%d outbox events, want 1", total)
+	}
+}
+
+func TestOutboxRelayKeepsOrderPerTopic(t *testing.T) {
+	var moved, closed []string
+	movedSender := &fakeSender{topic: messaging.TopicCardMoved, sent: &moved, failing: map[string]bool{"moved-2": true}}
+	closedSender := &fakeSender{topic: messaging.TopicCardClosed, sent: &closed}
+	db, relay := newOutboxRelayTest(t, movedSender, closedSender)
+
+	recordEvents(t, db,
+		outboxEntry{messaging.TopicCardMoved, "moved-1"},
+		outboxEntry{messaging.TopicCardMoved, "moved-2"},
+		outboxEntry{messaging.TopicCardClosed, "closed-1"},
+		outboxEntry{messaging.TopicCardMoved, "moved-3"},
+		outboxEntry{messaging.TopicCardClosed, "closed-2"},
+	)
+
+	published, err := relay.Relay(context.Background())
+	if err != nil {
+		t.Fatalf("Relay returned %v", err)
+	}
+
+	// moved-3 menunggu moved-2 supaya tidak menyalip, topic lain tetap jalan
+	if published != 3 || len(moved) != 1 || moved[0] != "moved-1" || len(closed) != 2 {
+		t.Fatalf("published %d, moved %v, closed %v", published, moved, closed)
+	}
+	if pending := pendingEventIds(t, db); len(pending) != 2 || pending[0] != "moved-2" || pending[1] != "moved-3" {
+		t.Fatalf("pending events are %v, want moved-2 and moved-3", pending)
+	}
+
+	failed := new(entity.OutboxEvent)
+	db.Where("event_id = ?", "moved-2").Take(failed)
+	if failed.Attempts != 1 || failed.LastError == nil {
+		t.Fatalf("failed event has %d attempts and last error %v", failed.Attempts, failed.LastError)
+	}
+
+	delete(movedSender.failing, "moved-2")
+	if _, err := relay.Relay(context.Background()); err != nil {
+		t.Fatalf("Relay returned %v", err)
+	}
+	if len(moved) != 3 || moved[1] != "moved-2" || moved[2] != "moved-3" {
+		t.Fatalf("moved events were sent as %v", moved)
+	}
+	if pending := pendingEventIds(t, db); len(pending) != 0 {
+		t.Fatalf("events %v are still pending", pending)
+	}
+}
+
+func TestOutboxRelayResendsUncommittedBatch(t *testing.T) {
+	var moved []string
+	ctx, cancel := context.WithCancel(context.Background())
+	// Worker berhenti setelah event pertama terkirim, sebelum batch di-commit
+	sender := &fakeSender{topic: messaging.TopicCardMoved, sent: &moved, afterSend: cancel}
+	db, relay := newOutboxRelayTest(t, sender)
+	recordEvents(t, db, outboxEntry{messaging.TopicCardMoved, "moved-1"}, outboxEntry{messaging.TopicCardMoved, "moved-2"})
+
+	if _, err := relay.Relay(ctx); err == nil {
+		t.Fatal("Relay committed a batch after the context was cancelled")
+	}
+	if pending := pendingEventIds(t, db); len(pending) != 2 {
+		t.Fatalf("pending events are %v after the failed commit, want both", pending)
+	}
+
+	sender.afterSend = nil
+	published, err := relay.Relay(context.Background())
+	if err != nil {
+		t.Fatalf("Relay returned %v", err)
+	}
+	if published != 2 || len(moved) != 4 || moved[2] != "moved-1" || moved[3] != "moved-2" {
+		t.Fatalf("published %d, sent %v, want the whole batch sent again", published, moved)
+	}
+}
+
+func TestOutboxRelaySkipsWhileAnotherWorkerHoldsTheLock(t *testing.T) {
+	var moved []string
+	db, relay := newOutboxRelayTest(t, &fakeSender{topic: messaging.TopicCardMoved, sent: &moved})
+	recordEvents(t, db, outboxEntry{messaging.TopicCardMoved, "moved-1"})
+
+	advisoryLockHeld.Store(true)
+	t.Cleanup(func() { advisoryLockHeld.Store(false) })
+
+	published, err := relay.Relay(context.Background())
+	if err != nil || published != 0 || len(moved) != 0 {
+		t.Fatalf("Relay published %d events %v with error %v while the lock was held", published, moved, err)
+	}
+
+	advisoryLockHeld.Store(false)
+	if published, err := relay.Relay(context.Background()); err != nil || published != 1 {
+		t.Fatalf("Relay published %d events with error %v after the lock was released", published, err)
+	}
+}
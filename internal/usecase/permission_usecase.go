@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+	"todo-app/internal/model/converter"
+	"todo-app/internal/repository"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PermissionUseCase struct {
+	DB                       *gorm.DB
+	Log                      *logrus.Logger
+	Validate                 *validator.Validate
+	RoleRepository           *repository.RoleRepository
+	RolePermissionRepository *repository.RolePermissionRepository
+	AuditLogRepository       *repository.AuditLogRepository
+}
+
+func NewPermissionUseCase(db *gorm.DB, logger *logrus.Logger, validate *validator.Validate,
+	roleRepository *repository.RoleRepository, rolePermissionRepository *repository.RolePermissionRepository,
+	auditLogRepository *repository.AuditLogRepository) *PermissionUseCase {
+	return &PermissionUseCase{
+		DB:                       db,
+		Log:                      logger,
+		Validate:                 validate,
+		RoleRepository:           roleRepository,
+		RolePermissionRepository: rolePermissionRepository,
+		AuditLogRepository:       auditLogRepository,
+	}
+}
+
+// Check reports whether the role has been granted every requested permission
+func (c *PermissionUseCase) Check(ctx context.Context, request *model.CheckPermissionRequest) (bool, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return false, fiber.ErrBadRequest
+	}
+
+	permissions := slices.Clone(request.Permissions)
+	slices.Sort(permissions)
+	permissions = slices.Compact(permissions)
+
+	total, err := c.RolePermissionRepository.CountGranted(tx, request.RoleId, permissions)
+	if err != nil {
+		c.Log.WithError(err).Error("error checking role permissions")
+		return false, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error checking role permissions")
+		return false, fiber.ErrInternalServerError
+	}
+
+	return total == int64(len(permissions)), nil
+}
+
+func (c *PermissionUseCase) Get(ctx context.Context, request *model.GetRolePermissionRequest) (*model.RolePermissionResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	role := new(entity.Role)
+	if err := c.RoleRepository.FindById(tx, role, request.RoleId); err != nil {
+		c.Log.WithError(err).Error("error getting role")
+		return nil, fiber.ErrNotFound
+	}
+
+	rolePermissions, err := c.RolePermissionRepository.FindByRoleId(tx, role.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting role permissions")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error getting role permissions")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.RolePermissionsToResponse(role.ID, rolePermissions), nil
+}
+
+// Update replaces the whole permission set of a role
+func (c *PermissionUseCase) Update(ctx context.Context, request *model.UpdateRolePermissionRequest) (*model.RolePermissionResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	for _, permission := range request.Permissions {
+		if !slices.Contains(model.Permissions, permission) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "unknown permission: "+permission)
+		}
+	}
+
+	role := new(entity.Role)
+	if err := c.RoleRepository.FindById(tx, role, request.RoleId); err != nil {
+		c.Log.WithError(err).Error("error getting role")
+		return nil, fiber.ErrNotFound
+	}
+
+	previous, err := c.RolePermissionRepository.FindByRoleId(tx, role.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting role permissions")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.RolePermissionRepository.DeleteByRoleId(tx, role.ID); err != nil {
+		c.Log.WithError(err).Error("error clearing role permissions")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	rolePermissions := make([]entity.RolePermission, len(request.Permissions))
+	for i, permission := range request.Permissions {
+		rolePermissions[i] = entity.RolePermission{
+			ID:         uuid.New().String(),
+			RoleId:     role.ID,
+			Permission: permission,
+		}
+		if err := c.RolePermissionRepository.Create(tx, &rolePermissions[i]); err != nil {
+			c.Log.WithError(err).Error("error granting role permission")
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	// Audit hanya mencatat permission yang benar-benar dicabut atau ditambahkan
+	for i := range previous {
+		if slices.Contains(request.Permissions, previous[i].Permission) {
+			continue
+		}
+		if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionDelete, &previous[i], nil); err != nil {
+			c.Log.WithError(err).Error("error writing audit log")
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+	for i := range rolePermissions {
+		granted := slices.ContainsFunc(previous, func(rolePermission entity.RolePermission) bool {
+			return rolePermission.Permission == rolePermissions[i].Permission
+		})
+		if granted {
+			continue
+		}
+		if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionCreate, nil, &rolePermissions[i]); err != nil {
+			c.Log.WithError(err).Error("error writing audit log")
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error updating role permissions")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.RolePermissionsToResponse(role.ID, rolePermissions), nil
+}
@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+	"todo-app/internal/model/converter"
+	"todo-app/internal/repository"
+	"todo-app/internal/util/helper"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// personalAccessTokenTouchInterval membatasi seberapa sering last_used_at ditulis ulang
+const personalAccessTokenTouchInterval = time.Minute
+
+type PersonalAccessTokenUseCase struct {
+	DB                            *gorm.DB
+	Log                           *logrus.Logger
+	Validate                      *validator.Validate
+	PersonalAccessTokenRepository *repository.PersonalAccessTokenRepository
+	UserRepository                *repository.UserRepository
+	RolePermissionRepository      *repository.RolePermissionRepository
+	AuditLogRepository            *repository.AuditLogRepository
+}
+
+func NewPersonalAccessTokenUseCase(db *gorm.DB, logger *logrus.Logger, validate *validator.Validate,
+	personalAccessTokenRepository *repository.PersonalAccessTokenRepository, userRepository *repository.UserRepository,
+	rolePermissionRepository *repository.RolePermissionRepository, auditLogRepository *repository.AuditLogRepository) *PersonalAccessTokenUseCase {
+	return &PersonalAccessTokenUseCase{
+		DB:                            db,
+		Log:                           logger,
+		Validate:                      validate,
+		PersonalAccessTokenRepository: personalAccessTokenRepository,
+		UserRepository:                userRepository,
+		RolePermissionRepository:      rolePermissionRepository,
+		AuditLogRepository:            auditLogRepository,
+	}
+}
+
+func (c *PersonalAccessTokenUseCase) Create(ctx context.Context, request *model.CreatePersonalAccessTokenRequest) (*model.PersonalAccessTokenResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	// Scope berupa permission harus dimiliki role, scope token saja boleh dipakai semua user
+	permissions := make([]string, 0, len(request.Scopes))
+	for _, scope := range request.Scopes {
+		switch {
+		case slices.Contains(model.Permissions, scope):
+			permissions = append(permissions, scope)
+		case slices.Contains(model.TokenScopes, scope):
+		default:
+			return nil, fiber.NewError(fiber.StatusBadRequest, "unknown scope "+scope)
+		}
+	}
+
+	now := time.Now()
+	if request.ExpiresAt != nil && !request.ExpiresAt.After(now) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "expires_at must be in the future")
+	}
+
+	// Token tidak boleh punya scope yang tidak dimiliki role pembuatnya
+	if len(permissions) > 0 {
+		granted, err := c.RolePermissionRepository.CountGranted(tx, request.RoleId, permissions)
+		if err != nil {
+			c.Log.WithError(err).Error("error checking role permissions")
+			return nil, fiber.ErrInternalServerError
+		}
+		if granted != int64(len(permissions)) {
+			return nil, fiber.NewError(fiber.StatusForbidden, "scopes exceed the permissions of your role")
+		}
+	}
+
+	token, err := helper.GeneratePersonalAccessToken()
+	if err != nil {
+		c.Log.WithError(err).Error("error generating personal access token")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	personalAccessToken := &entity.PersonalAccessToken{
+		ID:          uuid.New().String(),
+		UserId:      request.UserId,
+		Name:        request.Name,
+		TokenPrefix: token[:len(helper.PersonalAccessTokenPrefix)+8],
+		TokenHash:   helper.HashToken(token),
+		Scopes:      strings.Join(request.Scopes, ","),
+		ExpiresAt:   request.ExpiresAt,
+	}
+	if err := c.PersonalAccessTokenRepository.Create(tx, personalAccessToken); err != nil {
+		c.Log.WithError(err).Error("error creating personal access token")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionCreate, nil, personalAccessToken); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error creating personal access token")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Token asli hanya dikembalikan sekali di sini, yang disimpan hanya hash-nya
+	response := converter.PersonalAccessTokenToResponse(personalAccessToken)
+	response.Token = token
+	return response, nil
+}
+
+func (c *PersonalAccessTokenUseCase) List(ctx context.Context, request *model.ListPersonalAccessTokenRequest) ([]model.PersonalAccessTokenResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	tokens, err := c.PersonalAccessTokenRepository.FindActiveByUserId(tx, request.UserId, time.Now())
+	if err != nil {
+		c.Log.WithError(err).Error("error getting personal access tokens")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error getting personal access tokens")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.PersonalAccessTokenResponse, len(tokens))
+	for i, token := range tokens {
+		responses[i] = *converter.PersonalAccessTokenToResponse(&token)
+	}
+
+	return responses, nil
+}
+
+func (c *PersonalAccessTokenUseCase) Revoke(ctx context.Context, request *model.RevokePersonalAccessTokenRequest) (bool, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return false, fiber.ErrBadRequest
+	}
+
+	token := new(entity.PersonalAccessToken)
+	if err := c.PersonalAccessTokenRepository.FindByIdAndUserId(tx, token, request.ID, request.UserId); err != nil || token.RevokedAt != nil {
+		return false, fiber.ErrNotFound
+	}
+
+	before := *token
+	now := time.Now()
+	token.RevokedAt = &now
+	if err := c.PersonalAccessTokenRepository.Update(tx, token); err != nil {
+		c.Log.WithError(err).Error("error revoking personal access token")
+		return false, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionUpdate, &before, token); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return false, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error revoking personal access token")
+		return false, fiber.ErrInternalServerError
+	}
+
+	return true, nil
+}
+
+// Verify dipanggil auth middleware untuk token berprefix pat_
+func (c *PersonalAccessTokenUseCase) Verify(ctx context.Context, request *model.VerifyPersonalAccessTokenRequest) (*model.Auth, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrUnauthorized
+	}
+
+	token := new(entity.PersonalAccessToken)
+	if err := c.PersonalAccessTokenRepository.FindByTokenHash(tx, token, helper.HashToken(request.Token)); err != nil {
+		c.Log.WithError(err).Warn("personal access token not found")
+		return nil, fiber.ErrUnauthorized
+	}
+
+	now := time.Now()
+	if token.RevokedAt != nil || (token.ExpiresAt != nil && now.After(*token.ExpiresAt)) {
+		c.Log.Warnf("personal access token %s revoked or expired", token.ID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	// User yang dihapus atau dinonaktifkan tidak bisa memakai token lamanya
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, token.UserId); err != nil || !user.IsActive {
+		c.Log.Warnf("personal access token %s belongs to an unavailable user", token.ID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	if err := c.PersonalAccessTokenRepository.TouchLastUsed(tx, token.ID, now, now.Add(-personalAccessTokenTouchInterval)); err != nil {
+		c.Log.WithError(err).Error("error updating personal access token last used")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error verifying personal access token")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	auth := &model.Auth{
+		ID:                  user.ID,
+		RoleId:              user.RoleId,
+		DepartmentId:        user.DepartementId,
+		PersonalAccessToken: true,
+		Scopes:              strings.Split(token.Scopes, ","),
+		TokenId:             token.ID,
+	}
+	if token.ExpiresAt != nil {
+		auth.ExpiresAt = *token.ExpiresAt
+	}
+
+	return auth, nil
+}
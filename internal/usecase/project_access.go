@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"slices"
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+	"todo-app/internal/repository"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Roles that may change boards and cards, viewers only get read access
+var projectWriteRoles = []string{model.ProjectRoleOwner, model.ProjectRoleMaintainer}
+
+// checkProjectAccess makes sure the user is a member of the project and, when roles are given,
+// that the membership role is one of them
+func checkProjectAccess(tx *gorm.DB, log *logrus.Logger, projectUserRepository *repository.ProjectUserRepository,
+	projectId string, userId string, roles ...string) (*entity.ProjectUser, error) {
+	member := new(entity.ProjectUser)
+	if err := projectUserRepository.FindMember(tx, member, projectId, userId); err != nil {
+		log.Warnf("User %s is not a member of project %s : %+v", userId, projectId, err)
+		return nil, fiber.ErrForbidden
+	}
+
+	if len(roles) > 0 && !slices.Contains(roles, member.Role) {
+		log.Warnf("User %s with role %s is not allowed in project %s", userId, member.Role, projectId)
+		return nil, fiber.ErrForbidden
+	}
+
+	return member, nil
+}
@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"context"
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+	"todo-app/internal/model/converter"
+	"todo-app/internal/repository"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ProjectMemberUseCase struct {
+	DB                    *gorm.DB
+	Log                   *logrus.Logger
+	Validate              *validator.Validate
+	ProjectUserRepository *repository.ProjectUserRepository
+	UserRepository        *repository.UserRepository
+	AuditLogRepository    *repository.AuditLogRepository
+}
+
+func NewProjectMemberUseCase(db *gorm.DB, logger *logrus.Logger, validate *validator.Validate,
+	projectUserRepository *repository.ProjectUserRepository, userRepository *repository.UserRepository,
+	auditLogRepository *repository.AuditLogRepository) *ProjectMemberUseCase {
+	return &ProjectMemberUseCase{
+		DB:                    db,
+		Log:                   logger,
+		Validate:              validate,
+		ProjectUserRepository: projectUserRepository,
+		UserRepository:        userRepository,
+		AuditLogRepository:    auditLogRepository,
+	}
+}
+
+// checkLastOwner refuses to take away the owner role from the only owner left in the project.
+// The owner rows stay locked until the transaction ends, so concurrent demotions are checked one after another.
+func (c *ProjectMemberUseCase) checkLastOwner(tx *gorm.DB, member *entity.ProjectUser) error {
+	if member.Role != model.ProjectRoleOwner {
+		return nil
+	}
+
+	owners, err := c.ProjectUserRepository.LockByRole(tx, member.ProjectId, model.ProjectRoleOwner)
+	if err != nil {
+		c.Log.WithError(err).Error("error locking project owners")
+		return fiber.ErrInternalServerError
+	}
+	if len(owners) <= 1 {
+		return fiber.NewError(fiber.StatusConflict, "project must keep at least one owner")
+	}
+
+	return nil
+}
+
+func (c *ProjectMemberUseCase) Add(ctx context.Context, request *model.AddProjectMemberRequest) (*model.ProjectMemberResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	if _, err := checkProjectAccess(tx, c.Log, c.ProjectUserRepository, request.ProjectId, request.ActorId, model.ProjectRoleOwner); err != nil {
+		return nil, err
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.UserId); err != nil {
+		c.Log.WithError(err).Error("error getting user")
+		return nil, fiber.ErrNotFound
+	}
+
+	isMember, err := c.ProjectUserRepository.IsMember(tx, request.ProjectId, request.UserId)
+	if err != nil {
+		c.Log.WithError(err).Error("error checking project membership")
+		return nil, fiber.ErrInternalServerError
+	}
+	if isMember {
+		return nil, fiber.NewError(fiber.StatusConflict, "user is already a member of the project")
+	}
+
+	member := &entity.ProjectUser{
+		ID:        uuid.New().String(),
+		ProjectId: request.ProjectId,
+		UserId:    user.ID,
+		Role:      request.Role,
+	}
+
+	if err := c.ProjectUserRepository.Create(tx, member); err != nil {
+		c.Log.WithError(err).Error("error adding project member")
+		return nil, fiber.ErrInternalServerError
+	}
+	member.User = user
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionCreate, nil, member); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error adding project member")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ProjectMemberToResponse(member), nil
+}
+
+func (c *ProjectMemberUseCase) Update(ctx context.Context, request *model.UpdateProjectMemberRequest) (*model.ProjectMemberResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	if _, err := checkProjectAccess(tx, c.Log, c.ProjectUserRepository, request.ProjectId, request.ActorId, model.ProjectRoleOwner); err != nil {
+		return nil, err
+	}
+
+	member := new(entity.ProjectUser)
+	if err := c.ProjectUserRepository.FindMember(tx, member, request.ProjectId, request.UserId); err != nil {
+		c.Log.WithError(err).Error("error getting project member")
+		return nil, fiber.ErrNotFound
+	}
+
+	if request.Role != model.ProjectRoleOwner {
+		if err := c.checkLastOwner(tx, member); err != nil {
+			return nil, err
+		}
+	}
+
+	before := *member
+	member.Role = request.Role
+
+	if err := c.ProjectUserRepository.Update(tx, member); err != nil {
+		c.Log.WithError(err).Error("error updating project member")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionUpdate, &before, member); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error updating project member")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ProjectMemberToResponse(member), nil
+}
+
+func (c *ProjectMemberUseCase) Remove(ctx context.Context, request *model.RemoveProjectMemberRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return fiber.ErrBadRequest
+	}
+
+	if _, err := checkProjectAccess(tx, c.Log, c.ProjectUserRepository, request.ProjectId, request.ActorId, model.ProjectRoleOwner); err != nil {
+		return err
+	}
+
+	member := new(entity.ProjectUser)
+	if err := c.ProjectUserRepository.FindMember(tx, member, request.ProjectId, request.UserId); err != nil {
+		c.Log.WithError(err).Error("error getting project member")
+		return fiber.ErrNotFound
+	}
+
+	if err := c.checkLastOwner(tx, member); err != nil {
+		return err
+	}
+
+	if err := c.ProjectUserRepository.Delete(tx, member); err != nil {
+		c.Log.WithError(err).Error("error removing project member")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionDelete, member, nil); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error removing project member")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (c *ProjectMemberUseCase) Search(ctx context.Context, request *model.SearchProjectMemberRequest) ([]model.ProjectMemberResponse, int64, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	if _, err := checkProjectAccess(tx, c.Log, c.ProjectUserRepository, request.ProjectId, request.ActorId); err != nil {
+		return nil, 0, err
+	}
+
+	members, total, err := c.ProjectUserRepository.Search(tx, request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting project members")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error getting project members")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.ProjectMemberResponse, len(members))
+	for i, member := range members {
+		responses[i] = *converter.ProjectMemberToResponse(&member)
+	}
+
+	return responses, total, nil
+}
@@ -0,0 +1,304 @@
+package usecase
+
+import (
+	"context"
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+	"todo-app/internal/model/converter"
+	"todo-app/internal/repository"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ProjectUseCase struct {
+	DB                    *gorm.DB
+	Log                   *logrus.Logger
+	Validate              *validator.Validate
+	ProjectRepository     *repository.ProjectRepository
+	ProjectUserRepository *repository.ProjectUserRepository
+	AuditLogRepository    *repository.AuditLogRepository
+}
+
+func NewProjectUseCase(db *gorm.DB, logger *logrus.Logger, validate *validator.Validate,
+	projectRepository *repository.ProjectRepository, projectUserRepository *repository.ProjectUserRepository,
+	auditLogRepository *repository.AuditLogRepository) *ProjectUseCase {
+	return &ProjectUseCase{
+		DB:                    db,
+		Log:                   logger,
+		Validate:              validate,
+		ProjectRepository:     projectRepository,
+		ProjectUserRepository: projectUserRepository,
+		AuditLogRepository:    auditLogRepository,
+	}
+}
+
+func (c *ProjectUseCase) Create(ctx context.Context, request *model.CreateProjectRequest) (*model.ProjectResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	project := &entity.Project{
+		ID:           uuid.New().String(),
+		Name:         request.Name,
+		DepartmentId: request.DepartmentId,
+	}
+
+	if err := c.ProjectRepository.Create(tx, project); err != nil {
+		c.Log.WithError(err).Error("error creating project")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionCreate, nil, project); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// The creator becomes the first owner of the project
+	owner := &entity.ProjectUser{
+		ID:        uuid.New().String(),
+		ProjectId: project.ID,
+		UserId:    request.UserId,
+		Role:      model.ProjectRoleOwner,
+	}
+
+	if err := c.ProjectUserRepository.Create(tx, owner); err != nil {
+		c.Log.WithError(err).Error("error creating project owner")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionCreate, nil, owner); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error creating project")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ProjectToResponse(project), nil
+}
+
+func (c *ProjectUseCase) Update(ctx context.Context, request *model.UpdateProjectRequest) (*model.ProjectResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	project := new(entity.Project)
+	if err := c.ProjectRepository.FindById(tx, project, request.ID); err != nil {
+		c.Log.WithError(err).Error("error getting project")
+		return nil, fiber.ErrNotFound
+	}
+
+	before := *project
+	project.Name = request.Name
+	project.DepartmentId = request.DepartmentId
+
+	if err := c.ProjectRepository.Update(tx, project); err != nil {
+		c.Log.WithError(err).Error("error updating project")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionUpdate, &before, project); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error updating project")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ProjectToResponse(project), nil
+}
+
+func (c *ProjectUseCase) Get(ctx context.Context, request *model.GetProjectRequest) (*model.ProjectResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	project := new(entity.Project)
+	if err := c.ProjectRepository.FindById(tx, project, request.ID); err != nil {
+		c.Log.WithError(err).Error("error getting project")
+		return nil, fiber.ErrNotFound
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error getting project")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ProjectToResponse(project), nil
+}
+
+func (c *ProjectUseCase) SoftDelete(ctx context.Context, request *model.GetProjectRequest) (*model.ProjectResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	project := new(entity.Project)
+	if err := c.ProjectRepository.FindById(tx, project, request.ID); err != nil {
+		c.Log.WithError(err).Error("error getting project")
+		return nil, fiber.ErrNotFound
+	}
+
+	before := *project
+	if err := c.ProjectRepository.SoftDelete(tx, project); err != nil {
+		c.Log.WithError(err).Error("error soft deleting project")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionDelete, &before, project); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error soft deleting project")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ProjectToResponse(project), nil
+}
+
+func (c *ProjectUseCase) RecycleBin(ctx context.Context, request *model.SearchProjectRequest) ([]model.ProjectResponse, int64, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	projects, total, err := c.ProjectRepository.SearchTrashed(tx, request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting trashed projects")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error committing trashed projects")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.ProjectResponse, len(projects))
+	for i, project := range projects {
+		responses[i] = *converter.ProjectToResponse(&project)
+	}
+
+	return responses, total, nil
+}
+
+func (c *ProjectUseCase) Restore(ctx context.Context, request *model.GetProjectRequest) (*model.ProjectResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	if err := c.ProjectRepository.Restore(tx, request.ID); err != nil {
+		c.Log.WithError(err).Error("error restoring project")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	project := new(entity.Project)
+	if err := c.ProjectRepository.FindById(tx, project, request.ID); err != nil {
+		c.Log.WithError(err).Error("error getting project")
+		return nil, fiber.ErrNotFound
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionRestore, nil, project); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error restoring project")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ProjectToResponse(project), nil
+}
+
+func (c *ProjectUseCase) ForceDelete(ctx context.Context, request *model.DeleteProjectRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return fiber.ErrBadRequest
+	}
+
+	project := new(entity.Project)
+	if err := c.ProjectRepository.FindById(tx.Unscoped(), project, request.ID); err != nil {
+		c.Log.WithError(err).Error("error getting project")
+		return fiber.ErrNotFound
+	}
+
+	if err := c.ProjectRepository.ForceDelete(tx, request.ID); err != nil {
+		c.Log.WithError(err).Error("error force deleting project")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionForceDelete, project, nil); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error force deleting project")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (c *ProjectUseCase) Search(ctx context.Context, request *model.SearchProjectRequest) ([]model.ProjectResponse, int64, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	projects, total, err := c.ProjectRepository.Search(tx, request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting projects")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error getting projects")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.ProjectResponse, len(projects))
+	for i, project := range projects {
+		responses[i] = *converter.ProjectToResponse(&project)
+	}
+
+	return responses, total, nil
+}
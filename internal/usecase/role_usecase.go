@@ -15,19 +15,21 @@ import (
 )
 
 type RoleUseCase struct {
-	DB             *gorm.DB
-	Log            *logrus.Logger
-	Validate       *validator.Validate
-	RoleRepository *repository.RoleRepository
+	DB                 *gorm.DB
+	Log                *logrus.Logger
+	Validate           *validator.Validate
+	RoleRepository     *repository.RoleRepository
+	AuditLogRepository *repository.AuditLogRepository
 }
 
 func NewRoleUseCase(db *gorm.DB, logger *logrus.Logger, validate *validator.Validate,
-	roleRepository *repository.RoleRepository) *RoleUseCase {
+	roleRepository *repository.RoleRepository, auditLogRepository *repository.AuditLogRepository) *RoleUseCase {
 	return &RoleUseCase{
-		DB:             db,
-		Log:            logger,
-		Validate:       validate,
-		RoleRepository: roleRepository,
+		DB:                 db,
+		Log:                logger,
+		Validate:           validate,
+		RoleRepository:     roleRepository,
+		AuditLogRepository: auditLogRepository,
 	}
 }
 
@@ -50,6 +52,11 @@ func (c *RoleUseCase) Create(ctx context.Context, request *model.CreateRoleReque
 		return nil, fiber.ErrInternalServerError
 	}
 
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionCreate, nil, role); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		c.Log.WithError(err).Error("error creating role")
 		return nil, fiber.ErrInternalServerError
@@ -75,6 +82,7 @@ func (c *RoleUseCase) Update(ctx context.Context, request *model.UpdateRoleReque
 		return nil, fiber.ErrBadRequest
 	}
 
+	before := *role
 	role.Name = request.Name
 
 	if err := c.RoleRepository.Update(tx, role); err != nil {
@@ -82,6 +90,11 @@ func (c *RoleUseCase) Update(ctx context.Context, request *model.UpdateRoleReque
 		return nil, fiber.ErrInternalServerError
 	}
 
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionUpdate, &before, role); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		c.Log.WithError(err).Error("error updating role")
 		return nil, fiber.ErrInternalServerError
@@ -90,6 +103,43 @@ func (c *RoleUseCase) Update(ctx context.Context, request *model.UpdateRoleReque
 	return converter.RoleToResponse(role), nil
 }
 
+func (c *RoleUseCase) UpdateMfa(ctx context.Context, request *model.UpdateRoleMfaRequest) (*model.RoleResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	role := new(entity.Role)
+	if err := c.RoleRepository.FindById(tx, role, request.ID); err != nil {
+		c.Log.WithError(err).Error("error getting role")
+		return nil, fiber.ErrNotFound
+	}
+
+	// User dengan role ini yang belum enroll hanya bisa mengakses endpoint MFA sampai enrollment selesai
+	before := *role
+	role.MfaRequired = *request.MfaRequired
+
+	if err := c.RoleRepository.Update(tx, role); err != nil {
+		c.Log.WithError(err).Error("error updating role mfa requirement")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionUpdate, &before, role); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error updating role mfa requirement")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.RoleToResponse(role), nil
+}
+
 func (c *RoleUseCase) Get(ctx context.Context, request *model.GetRoleRequest) (*model.RoleResponse, error) {
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
@@ -124,10 +174,16 @@ func (c *RoleUseCase) SoftDelete(ctx context.Context, request *model.GetRoleRequ
 		return nil, fiber.ErrNotFound
 	}
 
+	before := *role
 	if err := c.RoleRepository.SoftDelete(tx, role); err != nil {
 		return nil, fiber.ErrInternalServerError
 	}
 
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionDelete, &before, role); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return nil, fiber.ErrInternalServerError
 	}
@@ -176,6 +232,11 @@ func (c *RoleUseCase) Restore(ctx context.Context, request *model.GetRoleRequest
 		return nil, fiber.ErrNotFound
 	}
 
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionRestore, nil, role); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return nil, fiber.ErrInternalServerError
 	}
@@ -187,10 +248,21 @@ func (c *RoleUseCase) ForceDelete(ctx context.Context, request *model.DeleteRole
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
+	// Data terakhir role disimpan di audit log sebelum dihapus permanen
+	role := new(entity.Role)
+	if err := c.RoleRepository.FindById(tx.Unscoped(), role, request.ID); err != nil {
+		return fiber.ErrNotFound
+	}
+
 	if err := c.RoleRepository.ForceDelete(tx, request.ID); err != nil {
 		return fiber.ErrInternalServerError
 	}
 
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionForceDelete, role, nil); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return fiber.ErrInternalServerError
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return fiber.ErrInternalServerError
 	}
@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+	"todo-app/internal/model/converter"
+	"todo-app/internal/repository"
+	"todo-app/internal/util/helper"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type SessionUseCase struct {
+	DB                        *gorm.DB
+	Log                       *logrus.Logger
+	Validate                  *validator.Validate
+	UserSessionRepository     *repository.UserSessionRepository
+	RefreshTokenRepository    *repository.RefreshTokenRepository
+	TokenRevocationRepository *repository.TokenRevocationRepository
+	JWTSigner                 *helper.JWTSigner
+	AuditLogRepository        *repository.AuditLogRepository
+}
+
+func NewSessionUseCase(db *gorm.DB, logger *logrus.Logger, validate *validator.Validate,
+	userSessionRepository *repository.UserSessionRepository, refreshTokenRepository *repository.RefreshTokenRepository,
+	tokenRevocationRepository *repository.TokenRevocationRepository, jwtSigner *helper.JWTSigner,
+	auditLogRepository *repository.AuditLogRepository) *SessionUseCase {
+	return &SessionUseCase{
+		DB:                        db,
+		Log:                       logger,
+		Validate:                  validate,
+		UserSessionRepository:     userSessionRepository,
+		RefreshTokenRepository:    refreshTokenRepository,
+		TokenRevocationRepository: tokenRevocationRepository,
+		JWTSigner:                 jwtSigner,
+		AuditLogRepository:        auditLogRepository,
+	}
+}
+
+// revokeSession ends a session: the session row, its refresh token family and every access token carrying its sid
+func revokeSession(ctx context.Context, tx *gorm.DB, userSessionRepository *repository.UserSessionRepository,
+	refreshTokenRepository *repository.RefreshTokenRepository, tokenRevocationRepository *repository.TokenRevocationRepository,
+	auditLogRepository *repository.AuditLogRepository, session *entity.UserSession, accessTokenTTL time.Duration, now time.Time) error {
+	before := *session
+	session.RevokedAt = &now
+	if err := userSessionRepository.Update(tx, session); err != nil {
+		return err
+	}
+
+	if err := recordAudit(ctx, tx, auditLogRepository, entity.AuditActionUpdate, &before, session); err != nil {
+		return err
+	}
+
+	if err := refreshTokenRepository.RevokeFamily(tx, session.ID, now); err != nil {
+		return err
+	}
+
+	return tokenRevocationRepository.Create(tx, &entity.TokenRevocation{
+		ID:        uuid.New().String(),
+		UserId:    session.UserId,
+		SessionId: &session.ID,
+		RevokedAt: now,
+		ExpiresAt: now.Add(accessTokenTTL),
+	})
+}
+
+// revokeAllSessions ends every session of the user, plus a user-wide revocation for access tokens issued before sessions existed
+func revokeAllSessions(ctx context.Context, tx *gorm.DB, userSessionRepository *repository.UserSessionRepository,
+	refreshTokenRepository *repository.RefreshTokenRepository, tokenRevocationRepository *repository.TokenRevocationRepository,
+	auditLogRepository *repository.AuditLogRepository, userId string, accessTokenTTL time.Duration, now time.Time) error {
+	sessions, err := userSessionRepository.FindActiveByUserId(tx, userId, now)
+	if err != nil {
+		return err
+	}
+
+	for i := range sessions {
+		if err := revokeSession(ctx, tx, userSessionRepository, refreshTokenRepository, tokenRevocationRepository, auditLogRepository, &sessions[i], accessTokenTTL, now); err != nil {
+			return err
+		}
+	}
+
+	if err := refreshTokenRepository.RevokeByUserId(tx, userId, now); err != nil {
+		return err
+	}
+
+	// Dibulatkan ke detik seperti iat, lihat TokenRevocationRepository.IsRevoked
+	return tokenRevocationRepository.Create(tx, &entity.TokenRevocation{
+		ID:        uuid.New().String(),
+		UserId:    userId,
+		RevokedAt: now.Truncate(time.Second),
+		ExpiresAt: now.Add(accessTokenTTL),
+	})
+}
+
+func (c *SessionUseCase) List(ctx context.Context, request *model.ListSessionRequest) ([]model.SessionResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body : %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	sessions, err := c.UserSessionRepository.FindActiveByUserId(tx, request.UserId, time.Now())
+	if err != nil {
+		c.Log.Warnf("Failed find user sessions : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		responses[i] = *converter.SessionToResponse(&session, request.CurrentSessionId)
+	}
+
+	return responses, nil
+}
+
+func (c *SessionUseCase) Revoke(ctx context.Context, request *model.RevokeSessionRequest) (bool, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body : %+v", err)
+		return false, fiber.ErrBadRequest
+	}
+
+	now := time.Now()
+
+	session := new(entity.UserSession)
+	if err := c.UserSessionRepository.FindActiveById(tx, session, request.ID, request.UserId, now); err != nil {
+		c.Log.Warnf("Failed find user session : %+v", err)
+		return false, fiber.ErrNotFound
+	}
+
+	if err := revokeSession(ctx, tx, c.UserSessionRepository, c.RefreshTokenRepository, c.TokenRevocationRepository, c.AuditLogRepository, session, c.JWTSigner.AccessTokenTTL, now); err != nil {
+		c.Log.Warnf("Failed revoke user session : %+v", err)
+		return false, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return false, fiber.ErrInternalServerError
+	}
+
+	return true, nil
+}
+
+func (c *SessionUseCase) RevokeAll(ctx context.Context, request *model.RevokeAllSessionRequest) (bool, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body : %+v", err)
+		return false, fiber.ErrBadRequest
+	}
+
+	if err := revokeAllSessions(ctx, tx, c.UserSessionRepository, c.RefreshTokenRepository, c.TokenRevocationRepository, c.AuditLogRepository, request.UserId, c.JWTSigner.AccessTokenTTL, time.Now()); err != nil {
+		c.Log.Warnf("Failed revoke user sessions : %+v", err)
+		return false, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return false, fiber.ErrInternalServerError
+	}
+
+	return true, nil
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+	"todo-app/internal/repository"
+)
+
+func TestRevokeAllSessionsRevokesTokensOfEarlierSeconds(t *testing.T) {
+	db := newTestDB(t)
+	log := newTestLogger()
+	tokenRevocationRepository := repository.NewTokenRevocationRepository(log)
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 700_000_000, time.UTC)
+	if err := revokeAllSessions(context.Background(), db, repository.NewUserSessionRepository(log),
+		repository.NewRefreshTokenRepository(log), tokenRevocationRepository, repository.NewAuditLogRepository(log),
+		"user-1", 15*time.Minute, now); err != nil {
+		t.Fatalf("revokeAllSessions returned %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		userId   string
+		issuedAt time.Time
+		revoked  bool
+	}{
+		{name: "issued a second earlier", userId: "user-1", issuedAt: now.Add(-time.Second).Truncate(time.Second), revoked: true},
+		{name: "issued in the same second", userId: "user-1", issuedAt: now.Truncate(time.Second), revoked: false},
+		{name: "issued a second later", userId: "user-1", issuedAt: now.Add(time.Second).Truncate(time.Second), revoked: false},
+		{name: "another user", userId: "user-2", issuedAt: now.Add(-time.Second).Truncate(time.Second), revoked: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revoked, err := tokenRevocationRepository.IsRevoked(db, tt.userId, "session-1", "token-1", tt.issuedAt)
+			if err != nil {
+				t.Fatalf("IsRevoked returned %v", err)
+			}
+			if revoked != tt.revoked {
+				t.Fatalf("IsRevoked is %t, want %t", revoked, tt.revoked)
+			}
+		})
+	}
+}
@@ -0,0 +1,359 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	"todo-app/internal/entity"
+	"todo-app/internal/gateway/messaging"
+	"todo-app/internal/model"
+	"todo-app/internal/model/converter"
+	"todo-app/internal/repository"
+	"todo-app/internal/util/helper"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type UserAdminUseCase struct {
+	DB                        *gorm.DB
+	Log                       *logrus.Logger
+	Validate                  *validator.Validate
+	UserRepository            *repository.UserRepository
+	RoleRepository            *repository.RoleRepository
+	DepartmentRepository      *repository.DepartmentRepository
+	ProjectUserRepository     *repository.ProjectUserRepository
+	UserSessionRepository     *repository.UserSessionRepository
+	RefreshTokenRepository    *repository.RefreshTokenRepository
+	TokenRevocationRepository *repository.TokenRevocationRepository
+	JWTSigner                 *helper.JWTSigner
+	AuditLogRepository        *repository.AuditLogRepository
+	OutboxEventRepository     *repository.OutboxEventRepository
+}
+
+func NewUserAdminUseCase(db *gorm.DB, logger *logrus.Logger, validate *validator.Validate,
+	userRepository *repository.UserRepository, roleRepository *repository.RoleRepository,
+	departmentRepository *repository.DepartmentRepository, projectUserRepository *repository.ProjectUserRepository,
+	userSessionRepository *repository.UserSessionRepository, refreshTokenRepository *repository.RefreshTokenRepository,
+	tokenRevocationRepository *repository.TokenRevocationRepository, jwtSigner *helper.JWTSigner,
+	auditLogRepository *repository.AuditLogRepository, outboxEventRepository *repository.OutboxEventRepository) *UserAdminUseCase {
+	return &UserAdminUseCase{
+		DB:                        db,
+		Log:                       logger,
+		Validate:                  validate,
+		UserRepository:            userRepository,
+		RoleRepository:            roleRepository,
+		DepartmentRepository:      departmentRepository,
+		ProjectUserRepository:     projectUserRepository,
+		UserSessionRepository:     userSessionRepository,
+		RefreshTokenRepository:    refreshTokenRepository,
+		TokenRevocationRepository: tokenRevocationRepository,
+		JWTSigner:                 jwtSigner,
+		AuditLogRepository:        auditLogRepository,
+		OutboxEventRepository:     outboxEventRepository,
+	}
+}
+
+func (c *UserAdminUseCase) Search(ctx context.Context, request *model.SearchUserRequest) ([]model.AdminUserResponse, int64, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	users, total, err := c.UserRepository.Search(tx, request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting users")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error getting users")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.AdminUserResponse, len(users))
+	for i, user := range users {
+		responses[i] = *converter.UserToAdminResponse(&user)
+	}
+
+	return responses, total, nil
+}
+
+func (c *UserAdminUseCase) Get(ctx context.Context, request *model.AdminGetUserRequest) (*model.AdminUserResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.ID); err != nil {
+		c.Log.WithError(err).Error("error getting user")
+		return nil, fiber.ErrNotFound
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error getting user")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.UserToAdminResponse(user), nil
+}
+
+func (c *UserAdminUseCase) Update(ctx context.Context, request *model.AdminUpdateUserRequest) (*model.AdminUserResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.ID); err != nil {
+		c.Log.WithError(err).Error("error getting user")
+		return nil, fiber.ErrNotFound
+	}
+
+	before := *user
+
+	// Role, department dan status aktif ikut tertanam di access token, jadi perubahan itu mengakhiri semua session
+	invalidateSessions := false
+
+	if request.Name != "" {
+		user.Name = request.Name
+	}
+
+	if request.RoleId != "" && request.RoleId != user.RoleId {
+		role := new(entity.Role)
+		if err := c.RoleRepository.FindById(tx, role, request.RoleId); err != nil {
+			c.Log.WithError(err).Error("error getting role")
+			return nil, fiber.NewError(fiber.StatusBadRequest, "role not found")
+		}
+		user.RoleId = role.ID
+		invalidateSessions = true
+	}
+
+	if request.DepartmentId != "" && request.DepartmentId != user.DepartementId {
+		department := new(entity.Department)
+		if err := c.DepartmentRepository.FindById(tx, department, request.DepartmentId); err != nil {
+			c.Log.WithError(err).Error("error getting department")
+			return nil, fiber.NewError(fiber.StatusBadRequest, "department not found")
+		}
+		user.DepartementId = department.ID
+		invalidateSessions = true
+	}
+
+	if request.IsActive != nil && *request.IsActive != user.IsActive {
+		if !*request.IsActive && user.ID == request.ActorId {
+			return nil, fiber.NewError(fiber.StatusConflict, "you cannot deactivate yourself")
+		}
+		user.IsActive = *request.IsActive
+		invalidateSessions = invalidateSessions || !user.IsActive
+	}
+
+	if err := c.UserRepository.Update(tx, user); err != nil {
+		c.Log.WithError(err).Error("error updating user")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionUpdate, &before, user); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if invalidateSessions {
+		if err := revokeAllSessions(ctx, tx, c.UserSessionRepository, c.RefreshTokenRepository, c.TokenRevocationRepository, c.AuditLogRepository, user.ID, c.JWTSigner.AccessTokenTTL, time.Now()); err != nil {
+			c.Log.WithError(err).Error("error revoking user sessions")
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if user.RoleId != before.RoleId {
+		event := converter.UserToRoleChangedEvent(user, before.RoleId, request.ActorId)
+		if err := recordEvent(ctx, tx, c.OutboxEventRepository, messaging.TopicRoleChanged, event); err != nil {
+			c.Log.WithError(err).Error("error writing role changed event")
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error updating user")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.UserToAdminResponse(user), nil
+}
+
+func (c *UserAdminUseCase) SoftDelete(ctx context.Context, request *model.AdminDeleteUserRequest) (*model.AdminUserResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.ID); err != nil {
+		c.Log.WithError(err).Error("error getting user")
+		return nil, fiber.ErrNotFound
+	}
+
+	if err := c.checkDeletable(tx, request); err != nil {
+		return nil, err
+	}
+
+	before := *user
+	if err := c.UserRepository.SoftDelete(tx, user); err != nil {
+		c.Log.WithError(err).Error("error deleting user")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionDelete, &before, user); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := revokeAllSessions(ctx, tx, c.UserSessionRepository, c.RefreshTokenRepository, c.TokenRevocationRepository, c.AuditLogRepository, user.ID, c.JWTSigner.AccessTokenTTL, time.Now()); err != nil {
+		c.Log.WithError(err).Error("error revoking user sessions")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error deleting user")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.UserToAdminResponse(user), nil
+}
+
+func (c *UserAdminUseCase) RecycleBin(ctx context.Context, request *model.SearchUserRequest) ([]model.AdminUserResponse, int64, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	users, total, err := c.UserRepository.SearchTrashed(tx, request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting trashed users")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error committing trashed users")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.AdminUserResponse, len(users))
+	for i, user := range users {
+		responses[i] = *converter.UserToAdminResponse(&user)
+	}
+
+	return responses, total, nil
+}
+
+func (c *UserAdminUseCase) Restore(ctx context.Context, request *model.AdminGetUserRequest) (*model.AdminUserResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	// Hanya user yang ada di recycle bin yang bisa dipulihkan
+	trashed := new(entity.User)
+	if err := c.UserRepository.FindById(tx.Unscoped().Where("deleted_at IS NOT NULL"), trashed, request.ID); err != nil {
+		c.Log.WithError(err).Error("error getting trashed user")
+		return nil, fiber.ErrNotFound
+	}
+
+	if err := c.UserRepository.Restore(tx, request.ID); err != nil {
+		c.Log.WithError(err).Error("error restoring user")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.ID); err != nil {
+		c.Log.WithError(err).Error("error getting user")
+		return nil, fiber.ErrNotFound
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionRestore, nil, user); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error restoring user")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.UserToAdminResponse(user), nil
+}
+
+func (c *UserAdminUseCase) ForceDelete(ctx context.Context, request *model.AdminDeleteUserRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("error validating request body")
+		return fiber.ErrBadRequest
+	}
+
+	if err := c.checkDeletable(tx, request); err != nil {
+		return err
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx.Unscoped(), user, request.ID); err != nil {
+		c.Log.WithError(err).Error("error getting user")
+		return fiber.ErrNotFound
+	}
+
+	// Session, token dan keanggotaan project ikut terhapus lewat ON DELETE CASCADE
+	if err := c.UserRepository.ForceDelete(tx, request.ID); err != nil {
+		c.Log.WithError(err).Error("error force deleting user")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionForceDelete, user, nil); err != nil {
+		c.Log.WithError(err).Error("error writing audit log")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("error force deleting user")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// checkDeletable menolak menghapus diri sendiri dan user yang masih menjadi satu-satunya owner project
+func (c *UserAdminUseCase) checkDeletable(tx *gorm.DB, request *model.AdminDeleteUserRequest) error {
+	if request.ID == request.ActorId {
+		return fiber.NewError(fiber.StatusConflict, "you cannot delete yourself")
+	}
+
+	total, err := c.ProjectUserRepository.CountSoleOwnerProjects(tx, request.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("error counting owned projects")
+		return fiber.ErrInternalServerError
+	}
+	if total > 0 {
+		return fiber.NewError(fiber.StatusConflict, "user is the only owner of one or more projects")
+	}
+
+	return nil
+}
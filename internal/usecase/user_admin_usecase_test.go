@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"todo-app/internal/entity"
+	"todo-app/internal/model"
+	"todo-app/internal/repository"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func TestRestoreOnlyRestoresTrashedUser(t *testing.T) {
+	db := newTestDB(t)
+	log := newTestLogger()
+	useCase := NewUserAdminUseCase(db, log, validator.New(), repository.NewUserRepository(log), repository.NewRoleRepository(log),
+		repository.NewDepartmentRepository(log), repository.NewProjectUserRepository(log), repository.NewUserSessionRepository(log),
+		repository.NewRefreshTokenRepository(log), repository.NewTokenRevocationRepository(log), nil,
+		repository.NewAuditLogRepository(log), repository.NewOutboxEventRepository(log))
+
+	active := &entity.User{ID: uuid.NewString(), Email: "jane@example.com", Name: "Jane", IsActive: true}
+	trashed := &entity.User{ID: uuid.NewString(), Email: "john@example.com", Name: "John", IsActive: true}
+	if err := db.Create([]*entity.User{active, trashed}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Delete(trashed).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		id     string
+		status int
+	}{
+		{name: "active user", id: active.ID, status: fiber.StatusNotFound},
+		{name: "unknown user", id: uuid.NewString(), status: fiber.StatusNotFound},
+		{name: "trashed user", id: trashed.ID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := useCase.Restore(context.Background(), &model.AdminGetUserRequest{ID: tt.id})
+			if tt.status != 0 {
+				assertStatus(t, err, tt.status)
+				return
+			}
+			if err != nil {
+				t.Fatalf("Restore returned %v", err)
+			}
+		})
+	}
+
+	// Hanya pemulihan yang benar-benar terjadi yang tercatat di audit
+	var restores []entity.AuditLog
+	db.Where("action = ?", entity.AuditActionRestore).Find(&restores)
+	if len(restores) != 1 || restores[0].EntityId != trashed.ID {
+		t.Fatalf("audit has restores %+v, want only %s", restores, trashed.ID)
+	}
+}
@@ -2,7 +2,13 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
 	"todo-app/internal/entity"
+	"todo-app/internal/gateway/mail"
+	"todo-app/internal/gateway/messaging"
 	"todo-app/internal/model"
 	"todo-app/internal/model/converter"
 	"todo-app/internal/repository"
@@ -16,21 +22,71 @@ import (
 	"gorm.io/gorm"
 )
 
+// UserTokenConfig holds the secret, lifetimes and frontend links of the tokens sent by email,
+// loaded from viper in config.NewUserTokenConfig. A link may be empty, then the mail only has the token.
+type UserTokenConfig struct {
+	Secret               []byte
+	EmailVerificationTTL time.Duration
+	PasswordResetTTL     time.Duration
+	VerifyEmailUrl       string
+	ResetPasswordUrl     string
+}
+
 type UserUseCase struct {
-	DB             *gorm.DB
-	Log            *logrus.Logger
-	Validate       *validator.Validate
-	UserRepository *repository.UserRepository
-	JWTHelper      *helper.JWTClaims
+	DB                        *gorm.DB
+	Log                       *logrus.Logger
+	Validate                  *validator.Validate
+	UserRepository            *repository.UserRepository
+	DepartmentRepository      *repository.DepartmentRepository
+	TokenRevocationRepository *repository.TokenRevocationRepository
+	RefreshTokenRepository    *repository.RefreshTokenRepository
+	UserSessionRepository     *repository.UserSessionRepository
+	UserTokenRepository       *repository.UserTokenRepository
+	LoginThrottleRepository   *repository.LoginThrottleRepository
+	LoginThrottle             *LoginThrottleConfig
+	PasswordPolicy            *helper.PasswordPolicy
+	UserMfaRepository         *repository.UserMfaRepository
+	RecoveryCodeRepository    *repository.UserRecoveryCodeRepository
+	RoleRepository            *repository.RoleRepository
+	Mailer                    mail.Mailer
+	JWTSigner                 *helper.JWTSigner
+	UserToken                 *UserTokenConfig
+	Mfa                       *MfaConfig
+	AuditLogRepository        *repository.AuditLogRepository
+	OutboxEventRepository     *repository.OutboxEventRepository
 }
 
 func NewUserUseCase(db *gorm.DB, logger *logrus.Logger, validate *validator.Validate,
-	userRepository *repository.UserRepository) *UserUseCase {
+	userRepository *repository.UserRepository, departmentRepository *repository.DepartmentRepository,
+	tokenRevocationRepository *repository.TokenRevocationRepository,
+	refreshTokenRepository *repository.RefreshTokenRepository, userSessionRepository *repository.UserSessionRepository,
+	userTokenRepository *repository.UserTokenRepository, loginThrottleRepository *repository.LoginThrottleRepository,
+	loginThrottle *LoginThrottleConfig, passwordPolicy *helper.PasswordPolicy,
+	userMfaRepository *repository.UserMfaRepository, recoveryCodeRepository *repository.UserRecoveryCodeRepository,
+	roleRepository *repository.RoleRepository, mailer mail.Mailer, jwtSigner *helper.JWTSigner,
+	userToken *UserTokenConfig, mfa *MfaConfig, auditLogRepository *repository.AuditLogRepository, outboxEventRepository *repository.OutboxEventRepository) *UserUseCase {
 	return &UserUseCase{
-		DB:             db,
-		Log:            logger,
-		Validate:       validate,
-		UserRepository: userRepository,
+		DB:                        db,
+		Log:                       logger,
+		Validate:                  validate,
+		UserRepository:            userRepository,
+		DepartmentRepository:      departmentRepository,
+		TokenRevocationRepository: tokenRevocationRepository,
+		RefreshTokenRepository:    refreshTokenRepository,
+		UserSessionRepository:     userSessionRepository,
+		UserTokenRepository:       userTokenRepository,
+		LoginThrottleRepository:   loginThrottleRepository,
+		LoginThrottle:             loginThrottle,
+		PasswordPolicy:            passwordPolicy,
+		UserMfaRepository:         userMfaRepository,
+		RecoveryCodeRepository:    recoveryCodeRepository,
+		RoleRepository:            roleRepository,
+		Mailer:                    mailer,
+		JWTSigner:                 jwtSigner,
+		UserToken:                 userToken,
+		Mfa:                       mfa,
+		AuditLogRepository:        auditLogRepository,
+		OutboxEventRepository:     outboxEventRepository,
 	}
 }
 
@@ -44,10 +100,21 @@ func (c *UserUseCase) Verify(ctx context.Context, request *model.VerifyUserReque
 		return nil, fiber.ErrBadRequest
 	}
 
-	user := new(entity.User)
-	if err := c.UserRepository.FindByToken(tx, user, request.Token); err != nil {
-		c.Log.Warnf("Failed find user by token : %+v", err)
-		return nil, fiber.ErrNotFound
+	// Signature, exp, nbf dan iss divalidasi tanpa lookup ke tabel users
+	claims, err := c.JWTSigner.ParseToken(request.Token)
+	if err != nil {
+		c.Log.Warnf("Invalid access token : %+v", err)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	revoked, err := c.TokenRevocationRepository.IsRevoked(tx, claims.UserID, claims.SessionId, claims.ID, claims.IssuedAt.Time)
+	if err != nil {
+		c.Log.Warnf("Failed check token revocation : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if revoked {
+		c.Log.Warnf("Access token %s has been revoked", claims.ID)
+		return nil, fiber.ErrUnauthorized
 	}
 
 	if err := tx.Commit().Error; err != nil {
@@ -55,7 +122,15 @@ func (c *UserUseCase) Verify(ctx context.Context, request *model.VerifyUserReque
 		return nil, fiber.ErrInternalServerError
 	}
 
-	return &model.Auth{ID: user.ID}, nil
+	return &model.Auth{
+		ID:           claims.UserID,
+		RoleId:       claims.RoleId,
+		DepartmentId: claims.DepartementId,
+		SessionId:    claims.SessionId,
+		MfaPending:   claims.MfaPending,
+		TokenId:      claims.ID,
+		ExpiresAt:    claims.ExpiresAt.Time,
+	}, nil
 }
 
 func (c *UserUseCase) Create(ctx context.Context, request *model.RegisterUserRequest) (*model.UserResponse, error) {
@@ -65,7 +140,7 @@ func (c *UserUseCase) Create(ctx context.Context, request *model.RegisterUserReq
 	err := c.Validate.Struct(request)
 	if err != nil {
 		c.Log.Warnf("Invalid request body : %+v", err)
-		return nil, fiber.ErrBadRequest
+		return nil, c.validationError(err, request.Password, request.Email, request.Name)
 	}
 
 	// Generate new UUID for user ID
@@ -84,11 +159,19 @@ func (c *UserUseCase) Create(ctx context.Context, request *model.RegisterUserReq
 		return nil, fiber.ErrConflict
 	}
 
+	department := new(entity.Department)
+	if err := c.DepartmentRepository.FindById(tx, department, request.DepartmentId); err != nil {
+		c.Log.Warnf("Failed find department by id : %+v", err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "department not found")
+	}
+
 	user := &entity.User{
-		ID:       userId,
-		Email:    request.Email,
-		Password: string(password),
-		Name:     request.Name,
+		ID:            userId,
+		Email:         request.Email,
+		Password:      string(password),
+		Name:          request.Name,
+		DepartementId: department.ID,
+		IsActive:      false,
 	}
 
 	if err := c.UserRepository.Create(tx, user); err != nil {
@@ -96,6 +179,178 @@ func (c *UserUseCase) Create(ctx context.Context, request *model.RegisterUserReq
 		return nil, fiber.ErrInternalServerError
 	}
 
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionCreate, nil, user); err != nil {
+		c.Log.Warnf("Failed write audit log : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// User baru belum aktif sampai email-nya diverifikasi
+	token, err := c.issueUserToken(tx, user.ID, entity.UserTokenPurposeEmailVerification, c.UserToken.EmailVerificationTTL)
+	if err != nil {
+		return nil, fiber.ErrInternalServerError
+	}
+
+	event := converter.UserToRegisteredEvent(user, model.UserRegistrationSourcePassword)
+	if err := recordEvent(ctx, tx, c.OutboxEventRepository, messaging.TopicUserRegistered, event); err != nil {
+		c.Log.Warnf("Failed write user registered event : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Gagal kirim email tidak membatalkan registrasi, user bisa minta kirim ulang
+	c.sendVerificationEmail(ctx, user, token)
+
+	return converter.UserToResponse(user), nil
+}
+
+// issueUserToken membatalkan token lama dengan purpose yang sama lalu menyimpan hash token yang baru
+func (c *UserUseCase) issueUserToken(tx *gorm.DB, userId string, purpose string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	if err := c.UserTokenRepository.InvalidateByUserId(tx, userId, purpose, now); err != nil {
+		c.Log.Warnf("Failed invalidate %s tokens : %+v", purpose, err)
+		return "", err
+	}
+
+	token, err := helper.GenerateSignedToken(c.UserToken.Secret, purpose)
+	if err != nil {
+		c.Log.Warnf("Failed generate %s token : %+v", purpose, err)
+		return "", err
+	}
+
+	userToken := &entity.UserToken{
+		ID:        uuid.New().String(),
+		UserId:    userId,
+		Purpose:   purpose,
+		TokenHash: helper.HashToken(token),
+		ExpiresAt: now.Add(ttl),
+	}
+	if err := c.UserTokenRepository.Create(tx, userToken); err != nil {
+		c.Log.Warnf("Failed create %s token : %+v", purpose, err)
+		return "", err
+	}
+
+	return token, nil
+}
+
+// findUserToken memvalidasi tanda tangan, masa berlaku, dan status pemakaian token tanpa menandainya terpakai
+func (c *UserUseCase) findUserToken(tx *gorm.DB, token string, purpose string, now time.Time) (*entity.UserToken, error) {
+	invalidToken := fiber.NewError(fiber.StatusBadRequest, "token is invalid or expired")
+
+	if !helper.VerifySignedToken(c.UserToken.Secret, purpose, token) {
+		c.Log.Warnf("Invalid %s token signature", purpose)
+		return nil, invalidToken
+	}
+
+	userToken := new(entity.UserToken)
+	if err := c.UserTokenRepository.FindByTokenHash(tx, userToken, helper.HashToken(token), purpose); err != nil {
+		c.Log.Warnf("Failed find %s token : %+v", purpose, err)
+		return nil, invalidToken
+	}
+
+	if userToken.UsedAt != nil || now.After(userToken.ExpiresAt) {
+		c.Log.Warnf("Token %s used or expired", userToken.ID)
+		return nil, invalidToken
+	}
+
+	return userToken, nil
+}
+
+// consumeUserToken memvalidasi token lalu menandainya sudah dipakai
+func (c *UserUseCase) consumeUserToken(tx *gorm.DB, token string, purpose string, now time.Time) (*entity.UserToken, error) {
+	userToken, err := c.findUserToken(tx, token, purpose, now)
+	if err != nil {
+		return nil, err
+	}
+
+	used, err := c.UserTokenRepository.MarkUsed(tx, userToken, now)
+	if err != nil {
+		c.Log.Warnf("Failed mark %s token used : %+v", purpose, err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if !used {
+		c.Log.Warnf("Token %s already used", userToken.ID)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "token is invalid or expired")
+	}
+
+	return userToken, nil
+}
+
+// validationError mengembalikan alasan dari password policy kalau request gagal di tag "password", selain itu 400 biasa
+func (c *UserUseCase) validationError(err error, password string, identifiers ...string) error {
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		for _, fieldError := range validationErrors {
+			if fieldError.Tag() != "password" {
+				continue
+			}
+			if reason := c.PasswordPolicy.Check(password, identifiers...); reason != nil {
+				return fiber.NewError(fiber.StatusBadRequest, reason.Error())
+			}
+		}
+	}
+	return fiber.ErrBadRequest
+}
+
+func (c *UserUseCase) sendVerificationEmail(ctx context.Context, user *entity.User, token string) {
+	c.sendTokenEmail(ctx, user, "Verifikasi email", "verifikasi email kamu", c.UserToken.VerifyEmailUrl, token)
+}
+
+func (c *UserUseCase) sendPasswordResetEmail(ctx context.Context, user *entity.User, token string) {
+	c.sendTokenEmail(ctx, user, "Reset password", "reset password kamu", c.UserToken.ResetPasswordUrl, token)
+}
+
+func (c *UserUseCase) sendTokenEmail(ctx context.Context, user *entity.User, subject string, action string, link string, token string) {
+	body := fmt.Sprintf("Halo %s,\n\nGunakan token berikut untuk %s:\n\n%s\n", user.Name, action, token)
+	if link != "" {
+		body += fmt.Sprintf("\nAtau buka link berikut:\n%s?token=%s\n", link, url.QueryEscape(token))
+	}
+
+	message := &mail.Message{
+		To:      user.Email,
+		Subject: subject,
+		Body:    body,
+	}
+	if err := c.Mailer.Send(ctx, message); err != nil {
+		c.Log.Warnf("Failed send %s email to user %s : %+v", subject, user.ID, err)
+	}
+}
+
+func (c *UserUseCase) VerifyEmail(ctx context.Context, request *model.VerifyEmailRequest) (*model.UserResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body : %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	userToken, err := c.consumeUserToken(tx, request.Token, entity.UserTokenPurposeEmailVerification, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, userToken.UserId); err != nil {
+		c.Log.Warnf("Failed find user by id : %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+
+	before := *user
+	user.IsActive = true
+	if err := c.UserRepository.Update(tx, user); err != nil {
+		c.Log.Warnf("Failed activate user : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionUpdate, &before, user); err != nil {
+		c.Log.Warnf("Failed write audit log : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		c.Log.Warnf("Failed commit transaction : %+v", err)
 		return nil, fiber.ErrInternalServerError
@@ -104,6 +359,40 @@ func (c *UserUseCase) Create(ctx context.Context, request *model.RegisterUserReq
 	return converter.UserToResponse(user), nil
 }
 
+func (c *UserUseCase) ResendVerificationEmail(ctx context.Context, request *model.ResendVerificationEmailRequest) (bool, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body : %+v", err)
+		return false, fiber.ErrBadRequest
+	}
+
+	// Selalu balas sukses supaya endpoint ini tidak bisa dipakai untuk menebak email terdaftar
+	user := new(entity.User)
+	if err := c.UserRepository.FindByEmail(tx, user, request.Email); err != nil {
+		c.Log.Warnf("Resend verification skipped, user not found : %+v", err)
+		return true, nil
+	}
+	if user.IsActive {
+		return true, nil
+	}
+
+	token, err := c.issueUserToken(tx, user.ID, entity.UserTokenPurposeEmailVerification, c.UserToken.EmailVerificationTTL)
+	if err != nil {
+		return false, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return false, fiber.ErrInternalServerError
+	}
+
+	c.sendVerificationEmail(ctx, user, token)
+
+	return true, nil
+}
+
 func (c *UserUseCase) Login(ctx context.Context, request *model.LoginUserRequest) (*model.UserResponse, error) {
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
@@ -114,32 +403,72 @@ func (c *UserUseCase) Login(ctx context.Context, request *model.LoginUserRequest
 		return nil, fiber.ErrBadRequest
 	}
 
+	now := time.Now()
+
+	// Counter gagal dihitung per akun (berdasarkan email, walaupun user tidak ada) dan per IP
+	attempt, err := c.reserveLoginAttempt(ctx, request.Email, request.IpAddress, now)
+	if err != nil {
+		return nil, err
+	}
+
 	// Cari user berdasarkan email
 	user := new(entity.User)
 	if err := c.UserRepository.FindByEmail(tx, user, request.Email); err != nil {
-		c.Log.Warnf("Failed find user by email : %+v", err)
+		c.Log.Debugf("Login failed, user not found : %+v", err)
+		c.recordLoginFailure(ctx, attempt)
 		return nil, fiber.ErrUnauthorized
 	}
 
 	// Bandingkan password hash
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(request.Password)); err != nil {
-		c.Log.Warnf("Invalid password : %+v", err)
+		c.Log.Debugf("Login failed, invalid password for user %s", user.ID)
+		c.recordLoginFailure(ctx, attempt)
 		return nil, fiber.ErrUnauthorized
 	}
 
-	// Generate Access Token (JWT 24 jam)
-	accessToken, expiresIn, err := helper.GenerateToken(user.ID, user.Email, user.RoleId, user.DepartementId, user.IsActive)
+	if !user.IsActive {
+		c.Log.Warnf("Login rejected, user %s is not active", user.ID)
+		c.releaseLoginAttempt(ctx, attempt)
+		return nil, fiber.NewError(fiber.StatusForbidden, "user is not active, please verify your email")
+	}
+
+	// Password benar, tahanan dilepas bersama data login lainnya
+	releaseLoginAttempt(tx, c.Log, c.LoginThrottleRepository, attempt)
+
+	// User dengan MFA aktif hanya mendapat challenge token, session dibuat setelah kode TOTP benar
+	mfaEnabled, err := c.UserMfaRepository.IsConfirmed(tx, user.ID)
 	if err != nil {
-		c.Log.Warnf("Failed to generate JWT access token : %+v", err)
+		c.Log.Warnf("Failed check mfa enrollment : %+v", err)
 		return nil, fiber.ErrInternalServerError
 	}
+	if mfaEnabled {
+		mfaToken, err := c.issueUserToken(tx, user.ID, entity.UserTokenPurposeMfaChallenge, c.Mfa.ChallengeTTL)
+		if err != nil {
+			return nil, fiber.ErrInternalServerError
+		}
+
+		if err := tx.Commit().Error; err != nil {
+			c.Log.Warnf("Failed commit transaction : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
 
-	// Generate Refresh Token (UUID/random string)
-	user.Token = accessToken
+		return converter.UserToMfaChallengeResponse(mfaToken, int64(c.Mfa.ChallengeTTL.Seconds())), nil
+	}
 
-	// Simpan refresh token ke DB
-	if err := c.UserRepository.Update(tx, user); err != nil {
-		c.Log.Warnf("Failed save user refresh token : %+v", err)
+	// Login berhasil, counter akun direset. Counter IP dibiarkan supaya tidak bisa direset dengan akun sendiri
+	if err := c.LoginThrottleRepository.DeleteByKey(tx, accountThrottleKey(request.Email)); err != nil {
+		c.Log.Warnf("Failed reset login throttle : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	pending, err := mfaPending(tx, c.RoleRepository, c.UserMfaRepository, user)
+	if err != nil {
+		c.Log.Warnf("Failed check mfa requirement : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response, err := c.startSession(ctx, tx, user, request.DeviceLabel, request.IpAddress, request.UserAgent, pending, now)
+	if err != nil {
 		return nil, fiber.ErrInternalServerError
 	}
 
@@ -149,7 +478,208 @@ func (c *UserUseCase) Login(ctx context.Context, request *model.LoginUserRequest
 		return nil, fiber.ErrInternalServerError
 	}
 
-	return converter.UserToTokenResponse(user, expiresIn), nil
+	return response, nil
+}
+
+func (c *UserUseCase) LoginMfa(ctx context.Context, request *model.LoginMfaRequest) (*model.UserResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body : %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	now := time.Now()
+
+	// Challenge token tidak langsung dipakai habis supaya salah ketik kode tidak memaksa login ulang
+	userToken, err := c.findUserToken(tx, request.MfaToken, entity.UserTokenPurposeMfaChallenge, now)
+	if err != nil {
+		return nil, fiber.ErrUnauthorized
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, userToken.UserId); err != nil {
+		c.Log.Warnf("Failed find user by id : %+v", err)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	// User bisa dinonaktifkan admin di antara langkah password dan langkah MFA
+	if !user.IsActive {
+		c.Log.Warnf("Login mfa rejected, user %s is not active", user.ID)
+		return nil, fiber.NewError(fiber.StatusForbidden, "user is not active")
+	}
+
+	mfa := new(entity.UserMfa)
+	if err := c.UserMfaRepository.FindByUserId(tx, mfa, user.ID); err != nil || mfa.ConfirmedAt == nil {
+		c.Log.Warnf("Login mfa failed, mfa not enabled for user %s", user.ID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	attempt, err := c.reserveLoginAttempt(ctx, user.Email, request.IpAddress, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var ok bool
+	if request.Code != "" {
+		ok, err = verifyTotp(tx, c.UserMfaRepository, mfa, request.Code, now)
+	} else {
+		ok, err = useRecoveryCode(tx, c.RecoveryCodeRepository, user.ID, request.RecoveryCode, now)
+	}
+	if err != nil {
+		c.Log.Warnf("Failed verify mfa code : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if !ok {
+		c.Log.Debugf("Login mfa failed, invalid code for user %s", user.ID)
+		c.recordLoginFailure(ctx, attempt)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	// Challenge dipakai habis secara atomic, request lain yang memakai challenge yang sama ditolak
+	used, err := c.UserTokenRepository.MarkUsed(tx, userToken, now)
+	if err != nil {
+		c.Log.Warnf("Failed mark mfa challenge used : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if !used {
+		c.Log.Warnf("Mfa challenge %s already used", userToken.ID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	// Tahanan dilepas di transaksi yang sama karena kode TOTP yang baru dipakai sudah ditulis di transaksi ini
+	releaseLoginAttempt(tx, c.Log, c.LoginThrottleRepository, attempt)
+	if err := c.LoginThrottleRepository.DeleteByKey(tx, accountThrottleKey(user.Email)); err != nil {
+		c.Log.Warnf("Failed reset login throttle : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response, err := c.startSession(ctx, tx, user, request.DeviceLabel, request.IpAddress, request.UserAgent, false, now)
+	if err != nil {
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return response, nil
+}
+
+// startSession membuat session baru beserta access token dan refresh token-nya.
+// Id session dipakai juga sebagai family refresh token.
+func (c *UserUseCase) startSession(ctx context.Context, tx *gorm.DB, user *entity.User, deviceLabel string, ipAddress string, userAgent string,
+	mfaPending bool, now time.Time) (*model.UserResponse, error) {
+	session := &entity.UserSession{
+		ID:          uuid.New().String(),
+		UserId:      user.ID,
+		DeviceLabel: deviceLabel,
+		IpAddress:   ipAddress,
+		UserAgent:   userAgent,
+		LastSeenAt:  now,
+		ExpiresAt:   now.Add(c.JWTSigner.RefreshTokenTTL),
+	}
+	if err := c.UserSessionRepository.Create(tx, session); err != nil {
+		c.Log.Warnf("Failed create user session : %+v", err)
+		return nil, err
+	}
+
+	// Login dicatat sebagai pembuatan session baru
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionLogin, nil, session); err != nil {
+		c.Log.Warnf("Failed write audit log : %+v", err)
+		return nil, err
+	}
+
+	// Generate Access Token (JWT)
+	accessToken, expiresIn, err := c.JWTSigner.GenerateToken(user.ID, user.Email, user.RoleId, user.DepartementId, session.ID, user.IsActive, mfaPending)
+	if err != nil {
+		c.Log.Warnf("Failed to generate JWT access token : %+v", err)
+		return nil, err
+	}
+
+	refreshToken, refreshExpiresIn, err := c.issueRefreshToken(tx, uuid.New().String(), user.ID, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.UserToTokenResponse(accessToken, expiresIn, refreshToken, refreshExpiresIn), nil
+}
+
+func (c *UserUseCase) reserveLoginAttempt(ctx context.Context, email string, ipAddress string, now time.Time) (*loginAttempt, error) {
+	limits := []loginThrottleLimit{{Key: accountThrottleKey(email), MaxAttempts: c.LoginThrottle.MaxAttempts}}
+	if ipAddress != "" {
+		limits = append(limits, loginThrottleLimit{Key: ipThrottleKey(ipAddress), MaxAttempts: c.LoginThrottle.IpMaxAttempts})
+	}
+	return reserveLoginAttempt(c.DB.WithContext(ctx), c.Log, c.LoginThrottleRepository, c.LoginThrottle, limits, now)
+}
+
+func (c *UserUseCase) releaseLoginAttempt(ctx context.Context, attempt *loginAttempt) {
+	releaseLoginAttempt(c.DB.WithContext(ctx), c.Log, c.LoginThrottleRepository, attempt)
+}
+
+func (c *UserUseCase) recordLoginFailure(ctx context.Context, attempt *loginAttempt) {
+	recordLoginFailure(c.DB.WithContext(ctx), c.Log, c.LoginThrottleRepository, c.LoginThrottle, attempt)
+}
+
+func (c *UserUseCase) Unlock(ctx context.Context, request *model.UnlockUserRequest) (*model.UserResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body : %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.ID); err != nil {
+		c.Log.Warnf("Failed find user by id : %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+
+	if err := c.LoginThrottleRepository.DeleteByKey(tx, accountThrottleKey(user.Email)); err != nil {
+		c.Log.Warnf("Failed unlock user : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Baris user tidak berubah, entri audit mencatat admin yang membuka kunci akun
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionUpdate, user, user); err != nil {
+		c.Log.Warnf("Failed write audit log : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.UserToResponse(user), nil
+}
+
+// issueRefreshToken menyimpan hash refresh token baru di family yang diberikan dan mengembalikan token aslinya
+func (c *UserUseCase) issueRefreshToken(tx *gorm.DB, id string, userId string, familyId string) (string, int64, error) {
+	token, err := helper.GenerateOpaqueToken()
+	if err != nil {
+		c.Log.Warnf("Failed to generate refresh token : %+v", err)
+		return "", 0, err
+	}
+
+	ttl := c.JWTSigner.RefreshTokenTTL
+	refreshToken := &entity.RefreshToken{
+		ID:        id,
+		UserId:    userId,
+		FamilyId:  familyId,
+		TokenHash: helper.HashToken(token),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+
+	if err := c.RefreshTokenRepository.Create(tx, refreshToken); err != nil {
+		c.Log.Warnf("Failed save refresh token : %+v", err)
+		return "", 0, err
+	}
+
+	return token, int64(ttl.Seconds()), nil
 }
 
 func (c *UserUseCase) Current(ctx context.Context, request *model.GetUserRequest) (*model.UserResponse, error) {
@@ -175,32 +705,186 @@ func (c *UserUseCase) Current(ctx context.Context, request *model.GetUserRequest
 	return converter.UserToResponse(user), nil
 }
 
-func (c *UserUseCase) Refresh(ctx context.Context, refreshToken string) (*model.UserResponse, error) {
+func (c *UserUseCase) ForgotPassword(ctx context.Context, request *model.ForgotPasswordRequest) (bool, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body : %+v", err)
+		return false, fiber.ErrBadRequest
+	}
+
+	// Selalu balas sukses supaya endpoint ini tidak bisa dipakai untuk menebak email terdaftar
+	user := new(entity.User)
+	if err := c.UserRepository.FindByEmail(tx, user, request.Email); err != nil {
+		c.Log.Warnf("Forgot password skipped, user not found : %+v", err)
+		return true, nil
+	}
+
+	token, err := c.issueUserToken(tx, user.ID, entity.UserTokenPurposePasswordReset, c.UserToken.PasswordResetTTL)
+	if err != nil {
+		return false, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return false, fiber.ErrInternalServerError
+	}
+
+	c.sendPasswordResetEmail(ctx, user, token)
+
+	return true, nil
+}
+
+func (c *UserUseCase) ResetPassword(ctx context.Context, request *model.ResetPasswordRequest) (bool, error) {
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
-	if refreshToken == "" {
-		return nil, fiber.NewError(fiber.StatusBadRequest, "refresh token required")
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body : %+v", err)
+		return false, c.validationError(err, request.Password)
+	}
+
+	now := time.Now()
+	userToken, err := c.consumeUserToken(tx, request.Token, entity.UserTokenPurposePasswordReset, now)
+	if err != nil {
+		return false, err
 	}
 
-	// Cari user berdasarkan refresh token
 	user := new(entity.User)
-	if err := c.UserRepository.FindByToken(tx, user, refreshToken); err != nil {
-		c.Log.Warnf("Refresh failed, user not found with token : %+v", err)
+	if err := c.UserRepository.FindById(tx, user, userToken.UserId); err != nil {
+		c.Log.Warnf("Failed find user by id : %+v", err)
+		return false, fiber.ErrNotFound
+	}
+
+	// Request reset tidak membawa email dan nama, jadi kemiripan dicek terhadap data user
+	if err := c.PasswordPolicy.Check(request.Password, user.Email, user.Name); err != nil {
+		c.Log.Warnf("Password rejected by policy : %+v", err)
+		return false, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	password, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
+	if err != nil {
+		c.Log.Warnf("Failed to generate bcrype hash : %+v", err)
+		return false, fiber.ErrInternalServerError
+	}
+
+	before := *user
+	user.Password = string(password)
+	user.Token = ""
+	if err := c.UserRepository.Update(tx, user); err != nil {
+		c.Log.Warnf("Failed update user password : %+v", err)
+		return false, fiber.ErrInternalServerError
+	}
+
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionUpdate, &before, user); err != nil {
+		c.Log.Warnf("Failed write audit log : %+v", err)
+		return false, fiber.ErrInternalServerError
+	}
+
+	// Password lama mungkin bocor, jadi semua session di semua device diakhiri
+	if err := revokeAllSessions(ctx, tx, c.UserSessionRepository, c.RefreshTokenRepository, c.TokenRevocationRepository, c.AuditLogRepository, user.ID, c.JWTSigner.AccessTokenTTL, now); err != nil {
+		c.Log.Warnf("Failed revoke user sessions : %+v", err)
+		return false, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return false, fiber.ErrInternalServerError
+	}
+
+	return true, nil
+}
+
+func (c *UserUseCase) Refresh(ctx context.Context, request *model.RefreshUserRequest) (*model.UserResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body : %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	current := new(entity.RefreshToken)
+	if err := c.RefreshTokenRepository.FindByTokenHash(tx, current, helper.HashToken(request.RefreshToken)); err != nil {
+		c.Log.Warnf("Refresh failed, refresh token not found : %+v", err)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	now := time.Now()
+
+	session := new(entity.UserSession)
+	if err := c.UserSessionRepository.FindActiveById(tx, session, current.FamilyId, current.UserId, now); err != nil {
+		c.Log.Warnf("Refresh failed, session %s not active : %+v", current.FamilyId, err)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	// Token yang sudah pernah dirotasi dipakai lagi, anggap family bocor dan akhiri session-nya
+	if current.RotatedAt != nil {
+		c.Log.Warnf("Refresh token reuse detected for family %s", current.FamilyId)
+		if err := revokeSession(ctx, tx, c.UserSessionRepository, c.RefreshTokenRepository, c.TokenRevocationRepository, c.AuditLogRepository, session, c.JWTSigner.AccessTokenTTL, now); err != nil {
+			c.Log.Errorf("Failed revoke refresh token family : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		if err := tx.Commit().Error; err != nil {
+			c.Log.Warnf("Failed commit transaction : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		return nil, fiber.ErrUnauthorized
+	}
+
+	if current.RevokedAt != nil || now.After(current.ExpiresAt) {
+		c.Log.Warnf("Refresh failed, refresh token %s revoked or expired", current.ID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, current.UserId); err != nil {
+		c.Log.Warnf("Refresh failed, user not found : %+v", err)
 		return nil, fiber.ErrUnauthorized
 	}
 
-	// Generate access token baru (expire 24 jam)
-	accessToken, expiresIn, err := helper.GenerateToken(user.ID, user.Email, user.RoleId, user.DepartementId, user.IsActive)
+	if !user.IsActive {
+		c.Log.Warnf("Refresh rejected, user %s is not active", user.ID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	pending, err := mfaPending(tx, c.RoleRepository, c.UserMfaRepository, user)
+	if err != nil {
+		c.Log.Errorf("Failed check mfa requirement : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Generate access token baru
+	accessToken, expiresIn, err := c.JWTSigner.GenerateToken(user.ID, user.Email, user.RoleId, user.DepartementId, session.ID, user.IsActive, pending)
 	if err != nil {
 		c.Log.Errorf("Failed generate new JWT : %+v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
-	user.Token = accessToken // ganti field di entity jadi RefreshToken
+	nextId := uuid.New().String()
+	refreshToken, refreshExpiresIn, err := c.issueRefreshToken(tx, nextId, user.ID, current.FamilyId)
+	if err != nil {
+		return nil, fiber.ErrInternalServerError
+	}
 
-	if err := c.UserRepository.Update(tx, user); err != nil {
-		c.Log.Errorf("Failed update user refresh token : %+v", err)
+	current.RotatedAt = &now
+	current.ReplacedBy = &nextId
+	if err := c.RefreshTokenRepository.Update(tx, current); err != nil {
+		c.Log.Errorf("Failed rotate refresh token : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	session.LastSeenAt = now
+	session.ExpiresAt = now.Add(time.Duration(refreshExpiresIn) * time.Second)
+	if request.IpAddress != "" {
+		session.IpAddress = request.IpAddress
+	}
+	if request.UserAgent != "" {
+		session.UserAgent = request.UserAgent
+	}
+	if err := c.UserSessionRepository.Update(tx, session); err != nil {
+		c.Log.Errorf("Failed update user session : %+v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
@@ -210,7 +894,7 @@ func (c *UserUseCase) Refresh(ctx context.Context, refreshToken string) (*model.
 	}
 
 	// Return token baru
-	return converter.UserToTokenResponse(user, expiresIn), nil
+	return converter.UserToTokenResponse(accessToken, expiresIn, refreshToken, refreshExpiresIn), nil
 }
 
 func (c *UserUseCase) Logout(ctx context.Context, request *model.LogoutUserRequest) (bool, error) {
@@ -222,16 +906,17 @@ func (c *UserUseCase) Logout(ctx context.Context, request *model.LogoutUserReque
 		return false, fiber.ErrBadRequest
 	}
 
-	user := new(entity.User)
-	if err := c.UserRepository.FindById(tx, user, request.ID); err != nil {
-		c.Log.Warnf("Failed find user by id : %+v", err)
+	now := time.Now()
+
+	// Hanya session yang sedang dipakai yang diakhiri, device lain tetap login
+	session := new(entity.UserSession)
+	if err := c.UserSessionRepository.FindActiveById(tx, session, request.SessionId, request.ID, now); err != nil {
+		c.Log.Warnf("Failed find user session : %+v", err)
 		return false, fiber.ErrNotFound
 	}
 
-	user.Token = ""
-
-	if err := c.UserRepository.Update(tx, user); err != nil {
-		c.Log.Warnf("Failed save user : %+v", err)
+	if err := revokeSession(ctx, tx, c.UserSessionRepository, c.RefreshTokenRepository, c.TokenRevocationRepository, c.AuditLogRepository, session, c.JWTSigner.AccessTokenTTL, now); err != nil {
+		c.Log.Warnf("Failed revoke user session : %+v", err)
 		return false, fiber.ErrInternalServerError
 	}
 
@@ -243,13 +928,57 @@ func (c *UserUseCase) Logout(ctx context.Context, request *model.LogoutUserReque
 	return true, nil
 }
 
+// checkCurrentPassword mengecek password baru terhadap policy dan password lama di bawah throttle login.
+// Dijalankan sebelum transaksi update membaca data, karena percobaan dipesan dan dicatat di luar transaksi tersebut.
+func (c *UserUseCase) checkCurrentPassword(ctx context.Context, request *model.UpdateUserRequest) error {
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(c.DB.WithContext(ctx), user, request.ID); err != nil {
+		c.Log.Warnf("Failed find user by id : %+v", err)
+		return fiber.ErrNotFound
+	}
+
+	// Email dan nama yang tidak ikut diubah tetap dipakai untuk cek kemiripan
+	email, name := user.Email, user.Name
+	if request.Email != "" {
+		email = request.Email
+	}
+	if request.Name != "" {
+		name = request.Name
+	}
+	if err := c.PasswordPolicy.Check(request.Password, email, name); err != nil {
+		c.Log.Warnf("Password rejected by policy : %+v", err)
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	// Password lama ditebak lewat session yang dicuri sama saja dengan menebak password login, jadi throttle-nya sama
+	attempt, err := c.reserveLoginAttempt(ctx, user.Email, request.IpAddress, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(request.CurrentPassword)); err != nil {
+		c.Log.Warnf("Failed update password, current password mismatch for user %s", user.ID)
+		c.recordLoginFailure(ctx, attempt)
+		return fiber.NewError(fiber.StatusBadRequest, "current password is incorrect")
+	}
+	c.releaseLoginAttempt(ctx, attempt)
+
+	return nil
+}
+
 func (c *UserUseCase) Update(ctx context.Context, request *model.UpdateUserRequest) (*model.UserResponse, error) {
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
 	if err := c.Validate.Struct(request); err != nil {
 		c.Log.Warnf("Invalid request body : %+v", err)
-		return nil, fiber.ErrBadRequest
+		return nil, c.validationError(err, request.Password, request.Email, request.Name)
+	}
+
+	if request.Password != "" {
+		if err := c.checkCurrentPassword(ctx, request); err != nil {
+			return nil, err
+		}
 	}
 
 	user := new(entity.User)
@@ -257,6 +986,7 @@ func (c *UserUseCase) Update(ctx context.Context, request *model.UpdateUserReque
 		c.Log.Warnf("Failed find user by id : %+v", err)
 		return nil, fiber.ErrNotFound
 	}
+	before := *user
 
 	// Check if email already exists (only if email is being updated)
 	if request.Email != "" {
@@ -288,6 +1018,11 @@ func (c *UserUseCase) Update(ctx context.Context, request *model.UpdateUserReque
 		return nil, fiber.ErrInternalServerError
 	}
 
+	if err := recordAudit(ctx, tx, c.AuditLogRepository, entity.AuditActionUpdate, &before, user); err != nil {
+		c.Log.Warnf("Failed write audit log : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		c.Log.Warnf("Failed commit transaction : %+v", err)
 		return nil, fiber.ErrInternalServerError
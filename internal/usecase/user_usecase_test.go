@@ -0,0 +1,261 @@
+package usecase
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+	"todo-app/internal/entity"
+	"todo-app/internal/gateway/mail"
+	"todo-app/internal/model"
+	"todo-app/internal/repository"
+	"todo-app/internal/util/helper"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+func newTestUserUseCase(t *testing.T, db *gorm.DB, log *logrus.Logger) *UserUseCase {
+	t.Helper()
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := helper.NewJWTSigner("todo-app", 15*time.Minute, 24*time.Hour, []*helper.SigningKey{{
+		Id: "test", Method: jwt.SigningMethodEdDSA, PrivateKey: privateKey, PublicKey: publicKey,
+	}}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy := &helper.PasswordPolicy{MinLength: 8, MinClasses: 3}
+	validate := validator.New()
+	if err := validate.RegisterValidation("password", policy.ValidateField); err != nil {
+		t.Fatal(err)
+	}
+
+	loginThrottle := &LoginThrottleConfig{MaxAttempts: 5, IpMaxAttempts: 20, BackoffBase: time.Minute,
+		BackoffMax: time.Hour, LockoutDuration: 15 * time.Minute, FailureWindow: 15 * time.Minute}
+
+	return NewUserUseCase(db, log, validate, repository.NewUserRepository(log), repository.NewDepartmentRepository(log),
+		repository.NewTokenRevocationRepository(log), repository.NewRefreshTokenRepository(log),
+		repository.NewUserSessionRepository(log), repository.NewUserTokenRepository(log),
+		repository.NewLoginThrottleRepository(log), loginThrottle, policy,
+		repository.NewUserMfaRepository(log), repository.NewUserRecoveryCodeRepository(log), repository.NewRoleRepository(log),
+		mail.NewLogMailer(log), signer, &UserTokenConfig{Secret: []byte("secret")}, &MfaConfig{ChallengeTTL: 5 * time.Minute},
+		repository.NewAuditLogRepository(log), repository.NewOutboxEventRepository(log))
+}
+
+func TestCreateReturnsPasswordPolicyReason(t *testing.T) {
+	db := newTestDB(t)
+	useCase := newTestUserUseCase(t, db, newTestLogger())
+	departmentId := uuid.NewString()
+	db.Create(&entity.Department{ID: departmentId, Name: "default"})
+
+	tests := []struct {
+		name     string
+		password string
+		reason   string
+	}{
+		{name: "too short", password: "Ab1!", reason: "password must be at least 8 characters"},
+		{name: "missing classes", password: "abcdefghij", reason: "password must contain at least 3 of: lowercase, uppercase, digit, symbol"},
+		{name: "similar to email", password: "Janedoe2024!", reason: "password is too similar to your email or name"},
+		{name: "common", password: "Password123!", reason: "password is too common"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := useCase.Create(context.Background(), &model.RegisterUserRequest{
+				Email: "jane.doe@example.com", Password: tt.password, Name: "Jane", DepartmentId: departmentId,
+			})
+
+			var fiberErr *fiber.Error
+			if !errors.As(err, &fiberErr) || fiberErr.Code != fiber.StatusBadRequest || fiberErr.Message != tt.reason {
+				t.Fatalf("Create returned %v, want 400 %q", err, tt.reason)
+			}
+		})
+	}
+}
+
+func TestUpdateThrottlesCurrentPasswordCheck(t *testing.T) {
+	db := newTestDB(t)
+	useCase := newTestUserUseCase(t, db, newTestLogger())
+
+	password, _ := bcrypt.GenerateFromPassword([]byte("Current-pass1"), bcrypt.MinCost)
+	user := &entity.User{ID: "user-1", Email: "jane@example.com", Name: "Jane", Password: string(password), IsActive: true}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	request := &model.UpdateUserRequest{ID: user.ID, Password: "Another-pass2", CurrentPassword: "wrong-pass", IpAddress: "10.0.0.1"}
+	_, err := useCase.Update(context.Background(), request)
+	assertStatus(t, err, fiber.StatusBadRequest)
+
+	// Percobaan berikutnya masih dalam backoff, password lama yang benar pun belum dicek
+	request.CurrentPassword = "Current-pass1"
+	_, err = useCase.Update(context.Background(), request)
+	assertStatus(t, err, fiber.StatusTooManyRequests)
+
+	if err := db.Model(new(entity.LoginThrottle)).Where("throttle_key IN ?",
+		[]string{accountThrottleKey(user.Email), ipThrottleKey(request.IpAddress)}).
+		Update("next_attempt_at", nil).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := useCase.Update(context.Background(), request); err != nil {
+		t.Fatalf("Update returned %v after the backoff", err)
+	}
+}
+
+func TestLoginReservesAttemptBeforePasswordCheck(t *testing.T) {
+	db := newTestDB(t)
+	useCase := newTestUserUseCase(t, db, newTestLogger())
+
+	password, _ := bcrypt.GenerateFromPassword([]byte("Current-pass1"), bcrypt.MinCost)
+	user := &entity.User{ID: "user-1", Email: "jane@example.com", Name: "Jane", Password: string(password), IsActive: true}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// Tebakan paralel memakai akun yang sama, hanya satu yang sampai ke pengecekan password
+	const guesses = 8
+	statuses := make(chan int, guesses)
+	var wg sync.WaitGroup
+	for range guesses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := useCase.Login(context.Background(), &model.LoginUserRequest{Email: user.Email, Password: "wrong-pass"})
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				statuses <- fiberErr.Code
+			} else {
+				statuses <- 0
+			}
+		}()
+	}
+	wg.Wait()
+	close(statuses)
+
+	counts := map[int]int{}
+	for status := range statuses {
+		counts[status]++
+	}
+	if counts[fiber.StatusUnauthorized] != 1 || counts[fiber.StatusTooManyRequests] != guesses-1 {
+		t.Fatalf("statuses are %v, want one 401 and %d 429", counts, guesses-1)
+	}
+
+	throttle := new(entity.LoginThrottle)
+	if err := db.Where("throttle_key = ?", accountThrottleKey(user.Email)).Take(throttle).Error; err != nil {
+		t.Fatal(err)
+	}
+	if throttle.FailedCount != 1 || throttle.NextAttemptAt == nil || throttle.LockedUntil != nil {
+		t.Fatalf("unexpected throttle %+v", throttle)
+	}
+}
+
+func TestLoginClearsAccountThrottleOnSuccess(t *testing.T) {
+	db := newTestDB(t)
+	useCase := newTestUserUseCase(t, db, newTestLogger())
+
+	password, _ := bcrypt.GenerateFromPassword([]byte("Current-pass1"), bcrypt.MinCost)
+	db.Create(&entity.Role{ID: "role-member", Name: "member"})
+	user := &entity.User{ID: "user-1", Email: "jane@example.com", Name: "Jane", Password: string(password), RoleId: "role-member", IsActive: true}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	request := &model.LoginUserRequest{Email: user.Email, Password: "wrong-pass", IpAddress: "10.0.0.1"}
+	_, err := useCase.Login(context.Background(), request)
+	assertStatus(t, err, fiber.StatusUnauthorized)
+
+	if err := db.Model(new(entity.LoginThrottle)).Where("1 = 1").Update("next_attempt_at", nil).Error; err != nil {
+		t.Fatal(err)
+	}
+	request.Password = "Current-pass1"
+	if _, err := useCase.Login(context.Background(), request); err != nil {
+		t.Fatalf("Login returned %v", err)
+	}
+
+	var account int64
+	db.Model(new(entity.LoginThrottle)).Where("throttle_key = ?", accountThrottleKey(user.Email)).Count(&account)
+	if account != 0 {
+		t.Fatal("successful login kept the account throttle")
+	}
+
+	// Counter IP tetap, tapi tahanan percobaan yang berhasil sudah dilepas
+	ip := new(entity.LoginThrottle)
+	if err := db.Where("throttle_key = ?", ipThrottleKey(request.IpAddress)).Take(ip).Error; err != nil {
+		t.Fatal(err)
+	}
+	if ip.FailedCount != 1 || ip.NextAttemptAt != nil {
+		t.Fatalf("unexpected ip throttle %+v", ip)
+	}
+	if _, err := useCase.Login(context.Background(), request); err != nil {
+		t.Fatalf("second Login returned %v", err)
+	}
+}
+
+func TestUnlockClearsThrottleAndRecordsAudit(t *testing.T) {
+	db := newTestDB(t)
+	useCase := newTestUserUseCase(t, db, newTestLogger())
+
+	user := &entity.User{ID: uuid.NewString(), Email: "jane@example.com", Name: "Jane", IsActive: true}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+	lockedUntil := time.Now().Add(time.Hour)
+	db.Create(&entity.LoginThrottle{Key: accountThrottleKey(user.Email), LastFailedAt: time.Now(), LockedUntil: &lockedUntil})
+
+	ctx := helper.WithAuditContext(context.Background(), &helper.AuditContext{ActorId: "admin-1"})
+	if _, err := useCase.Unlock(ctx, &model.UnlockUserRequest{ID: user.ID}); err != nil {
+		t.Fatalf("Unlock returned %v", err)
+	}
+
+	var throttles int64
+	db.Model(new(entity.LoginThrottle)).Count(&throttles)
+	if throttles != 0 {
+		t.Fatal("Unlock kept the account throttle")
+	}
+
+	auditLog := new(entity.AuditLog)
+	if err := db.Where("entity_type = ? AND entity_id = ?", "users", user.ID).Take(auditLog).Error; err != nil {
+		t.Fatalf("Unlock did not write an audit entry: %v", err)
+	}
+	if auditLog.Action != entity.AuditActionUpdate || auditLog.ActorId == nil || *auditLog.ActorId != "admin-1" {
+		t.Fatalf("unexpected audit entry %+v", auditLog)
+	}
+}
+
+func TestVerifyAcceptsIssuedAccessToken(t *testing.T) {
+	db := newTestDB(t)
+	useCase := newTestUserUseCase(t, db, newTestLogger())
+
+	password, _ := bcrypt.GenerateFromPassword([]byte("Current-pass1"), bcrypt.MinCost)
+	db.Create(&entity.Role{ID: "role-member", Name: "member"})
+	user := &entity.User{ID: "user-1", Email: "jane@example.com", Name: "Jane", Password: string(password), RoleId: "role-member", IsActive: true}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := useCase.Login(context.Background(), &model.LoginUserRequest{Email: user.Email, Password: "Current-pass1"})
+	if err != nil {
+		t.Fatalf("Login returned %v", err)
+	}
+
+	// Token yang ditandatangani key asimetris lebih panjang dari refresh token opaque
+	auth, err := useCase.Verify(context.Background(), &model.VerifyUserRequest{Token: response.Token})
+	if err != nil {
+		t.Fatalf("Verify rejected a token of %d characters: %v", len(response.Token), err)
+	}
+	if auth.ID != user.ID {
+		t.Fatalf("Verify returned user %s, want %s", auth.ID, user.ID)
+	}
+}
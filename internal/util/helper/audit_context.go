@@ -0,0 +1,24 @@
+package helper
+
+import "context"
+
+// AuditContext carries who made the request and from where, so use cases can write audit entries
+type AuditContext struct {
+	ActorId   string
+	IpAddress string
+	RequestId string
+}
+
+type auditContextKey struct{}
+
+func WithAuditContext(ctx context.Context, auditContext *AuditContext) context.Context {
+	return context.WithValue(ctx, auditContextKey{}, auditContext)
+}
+
+// GetAuditContext returns the audit context of the request, or an empty one for calls outside HTTP (seeders, workers)
+func GetAuditContext(ctx context.Context) *AuditContext {
+	if auditContext, ok := ctx.Value(auditContextKey{}).(*AuditContext); ok {
+		return auditContext
+	}
+	return &AuditContext{}
+}
@@ -1,12 +1,17 @@
 package helper
 
 import (
-	"log"
-	"os"
-	"strconv"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 type JWTClaims struct {
@@ -15,22 +20,116 @@ type JWTClaims struct {
 	RoleId        string `json:"role_id"`
 	DepartementId string `json:"department_id"`
 	IsActive      bool   `json:"is_active"`
+	SessionId     string `json:"sid"`
+	MfaPending    bool   `json:"mfa_pending,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func GenerateToken(userID, email, roleID, departementId string, isActive bool) (string, int64, error) {
-	// Load environment variables
-	jwtSecret := []byte(os.Getenv("JWT_SECRET"))
-	jwtIssuer := os.Getenv("JWT_ISSUER")
-	expHoursStr := os.Getenv("JWT_EXPIRATION_HOURS")
+// SigningKey is one RS256 or EdDSA key identified by its kid.
+// A key without PrivateKey can only verify, which is how a retired key stays valid until its tokens expire.
+type SigningKey struct {
+	Id         string
+	Method     jwt.SigningMethod
+	PrivateKey crypto.Signer
+	PublicKey  crypto.PublicKey
+}
+
+// JWTSigner signs access tokens with the active key and verifies them with any configured key
+type JWTSigner struct {
+	Issuer          string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	activeKey       *SigningKey
+	keys            map[string]*SigningKey
+	keyIds          []string
+}
+
+func NewJWTSigner(issuer string, accessTokenTTL time.Duration, refreshTokenTTL time.Duration,
+	keys []*SigningKey, activeKeyId string) (*JWTSigner, error) {
+	signer := &JWTSigner{
+		Issuer:          issuer,
+		AccessTokenTTL:  accessTokenTTL,
+		RefreshTokenTTL: refreshTokenTTL,
+		keys:            make(map[string]*SigningKey, len(keys)),
+	}
+
+	for _, key := range keys {
+		if _, ok := signer.keys[key.Id]; ok {
+			return nil, fmt.Errorf("duplicate jwt key id %q", key.Id)
+		}
+		signer.keys[key.Id] = key
+		signer.keyIds = append(signer.keyIds, key.Id)
+	}
+
+	activeKey, ok := signer.keys[activeKeyId]
+	if !ok {
+		return nil, fmt.Errorf("active jwt key %q is not configured", activeKeyId)
+	}
+	if activeKey.PrivateKey == nil {
+		return nil, fmt.Errorf("active jwt key %q has no private key", activeKeyId)
+	}
+	signer.activeKey = activeKey
+
+	return signer, nil
+}
+
+// ParseSigningKey reads a PEM encoded RSA or Ed25519 key. A private key (PKCS#1 or PKCS#8) can sign,
+// a public key (PKIX) can only verify.
+func ParseSigningKey(id string, pemBytes []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, fmt.Errorf("jwt key %q is not PEM encoded", id)
+	}
+
+	var parsed any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("jwt key %q has unsupported PEM type %q", id, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("jwt key %q: %w", id, err)
+	}
+
+	key := &SigningKey{Id: id}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.Method, key.PrivateKey, key.PublicKey = jwt.SigningMethodRS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.Method, key.PublicKey = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.Method, key.PrivateKey, key.PublicKey = jwt.SigningMethodEdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.Method, key.PublicKey = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("jwt key %q must be an RSA or Ed25519 key", id)
+	}
+
+	if rsaKey, ok := key.PublicKey.(*rsa.PublicKey); ok && rsaKey.N.BitLen() < 2048 {
+		return nil, fmt.Errorf("jwt key %q: RSA keys must be at least 2048 bits", id)
+	}
+
+	return key, nil
+}
 
-	jwtExpHours, err := strconv.Atoi(expHoursStr)
-	if err != nil || jwtExpHours <= 0 {
-		jwtExpHours = 1
-		log.Println("⚠️ JWT_EXPIRATION_HOURS tidak ditemukan, default ke 1 jam")
+// Keys returns every configured key in configuration order, used to publish the JWKS
+func (s *JWTSigner) Keys() []*SigningKey {
+	keys := make([]*SigningKey, len(s.keyIds))
+	for i, id := range s.keyIds {
+		keys[i] = s.keys[id]
 	}
+	return keys
+}
 
-	expiresIn := int64(jwtExpHours * 3600)
+func (s *JWTSigner) GenerateToken(userID, email, roleID, departementId, sessionId string, isActive, mfaPending bool) (string, int64, error) {
+	now := time.Now()
+	expiresIn := int64(s.AccessTokenTTL.Seconds())
 
 	claims := &JWTClaims{
 		UserID:        userID,
@@ -38,19 +137,55 @@ func GenerateToken(userID, email, roleID, departementId string, isActive bool) (
 		RoleId:        roleID,
 		DepartementId: departementId,
 		IsActive:      isActive,
+		SessionId:     sessionId,
+		MfaPending:    mfaPending,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(jwtExpHours) * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
-			Issuer:    jwtIssuer,
+			ID:        uuid.NewString(),
+			Subject:   userID,
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.AccessTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    s.Issuer,
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString(jwtSecret)
+	token := jwt.NewWithClaims(s.activeKey.Method, claims)
+	token.Header["kid"] = s.activeKey.Id
+	signedToken, err := token.SignedString(s.activeKey.PrivateKey)
 	if err != nil {
 		return "", 0, err
 	}
 
 	return signedToken, expiresIn, nil
 }
+
+// ParseToken validates the signature (by kid), exp, nbf and iss of a token signed by GenerateToken and returns its claims
+func (s *JWTSigner) ParseToken(tokenString string) (*JWTClaims, error) {
+	claims := new(JWTClaims)
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := s.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown jwt key %q", kid)
+		}
+		// Algoritma harus sesuai dengan key-nya, bukan sekadar salah satu yang diizinkan
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, fmt.Errorf("jwt key %q does not sign with %s", kid, token.Method.Alg())
+		}
+		return key.PublicKey, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithIssuer(s.Issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.UserID == "" || claims.ID == "" {
+		return nil, errors.New("token is missing user or token id")
+	}
+
+	return claims, nil
+}
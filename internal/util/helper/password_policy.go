@@ -0,0 +1,144 @@
+package helper
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+//go:embed common_passwords.txt
+var commonPasswordsFile string
+
+var commonPasswords = loadCommonPasswords()
+
+func loadCommonPasswords() map[string]struct{} {
+	passwords := make(map[string]struct{})
+	scanner := bufio.NewScanner(strings.NewReader(commonPasswordsFile))
+	for scanner.Scan() {
+		if password := strings.TrimSpace(scanner.Text()); password != "" {
+			passwords[strings.ToLower(password)] = struct{}{}
+		}
+	}
+	return passwords
+}
+
+// PasswordPolicy is the rule set used by the "password" validator tag and by use cases that set a password
+type PasswordPolicy struct {
+	MinLength  int
+	MinClasses int
+}
+
+// Check validates the password; identifiers (email, name) must not appear in the password
+func (p *PasswordPolicy) Check(password string, identifiers ...string) error {
+	if len([]rune(password)) < p.MinLength {
+		return fmt.Errorf("password must be at least %d characters", p.MinLength)
+	}
+
+	if countCharacterClasses(password) < p.MinClasses {
+		return fmt.Errorf("password must contain at least %d of: lowercase, uppercase, digit, symbol", p.MinClasses)
+	}
+
+	lower := strings.ToLower(password)
+	for _, identifier := range identifiers {
+		for _, part := range identifierParts(identifier) {
+			if strings.Contains(lower, part) || strings.Contains(part, lower) {
+				return errors.New("password is too similar to your email or name")
+			}
+		}
+	}
+
+	if isCommonPassword(lower) {
+		return errors.New("password is too common")
+	}
+
+	return nil
+}
+
+// ValidateField is the "password" validator tag, the password is compared with the Email and Name fields of the same struct.
+// The tag only reports a bool, use cases call Check again to return the reason.
+func (p *PasswordPolicy) ValidateField(fl validator.FieldLevel) bool {
+	password := fl.Field().String()
+	if password == "" {
+		return true
+	}
+	return p.Check(password, siblingString(fl.Parent(), "Email"), siblingString(fl.Parent(), "Name")) == nil
+}
+
+func siblingString(parent reflect.Value, name string) string {
+	if parent.Kind() == reflect.Pointer {
+		parent = parent.Elem()
+	}
+	if parent.Kind() != reflect.Struct {
+		return ""
+	}
+	field := parent.FieldByName(name)
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return ""
+	}
+	return field.String()
+}
+
+func countCharacterClasses(password string) int {
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	count := 0
+	for _, ok := range []bool{lower, upper, digit, symbol} {
+		if ok {
+			count++
+		}
+	}
+	return count
+}
+
+// identifierParts memecah email dan nama menjadi potongan yang cukup panjang untuk dibandingkan
+func identifierParts(identifier string) []string {
+	identifier = strings.ToLower(strings.TrimSpace(identifier))
+	if local, _, ok := strings.Cut(identifier, "@"); ok {
+		identifier = local
+	}
+
+	fields := strings.FieldsFunc(identifier, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	parts := make([]string, 0, len(fields)+1)
+	if joined := strings.Join(fields, ""); len(joined) >= 3 {
+		parts = append(parts, joined)
+	}
+	for _, field := range fields {
+		if len(field) >= 3 {
+			parts = append(parts, field)
+		}
+	}
+	return parts
+}
+
+// isCommonPassword juga mengecek versi tanpa angka/simbol di belakang, misalnya "Password123!" -> "password"
+func isCommonPassword(lower string) bool {
+	if _, ok := commonPasswords[lower]; ok {
+		return true
+	}
+	trimmed := strings.TrimRightFunc(lower, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	_, ok := commonPasswords[trimmed]
+	return ok
+}
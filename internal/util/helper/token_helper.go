@@ -0,0 +1,62 @@
+package helper
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// GenerateOpaqueToken membuat random token yang aman dikirim di URL atau body
+func GenerateOpaqueToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// HashToken membuat hash sha256 dari token, hanya hash ini yang disimpan di database
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateSignedToken membuat opaque token yang ditandatangani HMAC dengan secret.
+// Purpose ikut ditandatangani sehingga token untuk satu keperluan tidak bisa dipakai untuk keperluan lain.
+func GenerateSignedToken(secret []byte, purpose string) (string, error) {
+	token, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	return token + "." + signToken(secret, purpose, token), nil
+}
+
+// VerifySignedToken mengecek tanda tangan token sebelum dicari ke database
+func VerifySignedToken(secret []byte, purpose string, signedToken string) bool {
+	token, signature, ok := strings.Cut(signedToken, ".")
+	if !ok || token == "" {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(signToken(secret, purpose, token)))
+}
+
+func signToken(secret []byte, purpose string, token string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(purpose + "." + token))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// PersonalAccessTokenPrefix membedakan personal access token dari JWT di header Authorization
+const PersonalAccessTokenPrefix = "pat_"
+
+// GeneratePersonalAccessToken membuat token berprefix pat_ untuk script dan CI
+func GeneratePersonalAccessToken() (string, error) {
+	token, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	return PersonalAccessTokenPrefix + token, nil
+}